@@ -3,21 +3,41 @@ package aws
 import (
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/tailpipe-plugin-aws/config"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudtrail_lake"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudtrail_lookup_events"
 	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-aws/sources/guardduty"
 	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
-	"github.com/turbot/tailpipe-plugin-aws/tables/alb_connection_log"
+	"github.com/turbot/tailpipe-plugin-aws/sources/securityhub"
+	"github.com/turbot/tailpipe-plugin-aws/tables/access_analyzer_finding"
 	"github.com/turbot/tailpipe-plugin-aws/tables/alb_access_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/alb_connection_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/api_gateway_access_log"
 	"github.com/turbot/tailpipe-plugin-aws/tables/clb_access_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/cloudfront_access_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/cloudtrail_digest"
+	"github.com/turbot/tailpipe-plugin-aws/tables/cloudtrail_insight"
 	"github.com/turbot/tailpipe-plugin-aws/tables/cloudtrail_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/config_configuration_item"
+	"github.com/turbot/tailpipe-plugin-aws/tables/config_rule_compliance"
 	"github.com/turbot/tailpipe-plugin-aws/tables/cost_and_usage_focus"
 	"github.com/turbot/tailpipe-plugin-aws/tables/cost_and_usage_report"
 	"github.com/turbot/tailpipe-plugin-aws/tables/cost_optimization_recommendation"
+	"github.com/turbot/tailpipe-plugin-aws/tables/eks_audit_log"
 	"github.com/turbot/tailpipe-plugin-aws/tables/guardduty_finding"
+	"github.com/turbot/tailpipe-plugin-aws/tables/health_event"
+	"github.com/turbot/tailpipe-plugin-aws/tables/inspector_finding"
+	"github.com/turbot/tailpipe-plugin-aws/tables/lambda_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/macie_finding"
+	"github.com/turbot/tailpipe-plugin-aws/tables/network_firewall_log"
 	"github.com/turbot/tailpipe-plugin-aws/tables/nlb_access_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/route53_public_dns_query_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/route53_resolver_query_log"
 	"github.com/turbot/tailpipe-plugin-aws/tables/s3_server_access_log"
+	"github.com/turbot/tailpipe-plugin-aws/tables/securityhub_finding"
+	"github.com/turbot/tailpipe-plugin-aws/tables/transit_gateway_flow_log"
 	"github.com/turbot/tailpipe-plugin-aws/tables/vpc_flow_log"
 	"github.com/turbot/tailpipe-plugin-aws/tables/waf_traffic_log"
-	"github.com/turbot/tailpipe-plugin-aws/tables/securityhub_finding"
 	"github.com/turbot/tailpipe-plugin-sdk/plugin"
 	"github.com/turbot/tailpipe-plugin-sdk/row_source"
 	"github.com/turbot/tailpipe-plugin-sdk/table"
@@ -31,27 +51,51 @@ func init() {
 	// Register tables, with type parameters:
 	// 1. row struct
 	// 2. table implementation
+	table.RegisterTable[*access_analyzer_finding.AccessAnalyzerFinding, *access_analyzer_finding.AccessAnalyzerFindingTable]()
 	table.RegisterTable[*alb_access_log.AlbAccessLog, *alb_access_log.AlbAccessLogTable]()
+	table.RegisterTable[*alb_connection_log.AlbConnectionLog, *alb_connection_log.AlbConnectionLogTable]()
 	table.RegisterTable[*clb_access_log.ClbAccessLog, *clb_access_log.ClbAccessLogTable]()
+	table.RegisterTable[*cloudfront_access_log.CloudFrontAccessLog, *cloudfront_access_log.CloudFrontAccessLogTable]()
+	table.RegisterTable[*cloudtrail_digest.CloudTrailDigest, *cloudtrail_digest.CloudTrailDigestTable]()
+	table.RegisterTable[*cloudtrail_insight.CloudTrailInsight, *cloudtrail_insight.CloudTrailInsightTable]()
 	table.RegisterTable[*cloudtrail_log.CloudTrailLog, *cloudtrail_log.CloudTrailLogTable]()
+	table.RegisterTable[*config_configuration_item.ConfigConfigurationItem, *config_configuration_item.ConfigConfigurationItemTable]()
+	table.RegisterTable[*config_rule_compliance.ConfigRuleCompliance, *config_rule_compliance.ConfigRuleComplianceTable]()
 	table.RegisterTable[*cost_and_usage_focus.CostUsageFocus, *cost_and_usage_focus.CostUsageFocusTable]()
 	table.RegisterTable[*cost_and_usage_report.CostUsageReport, *cost_and_usage_report.CostUsageReportTable]()
 	table.RegisterTable[*cost_optimization_recommendation.CostOptimizationRecommendation, *cost_optimization_recommendation.CostOptimizationRecommendationsTable]()
+	table.RegisterTable[*eks_audit_log.EksAuditLog, *eks_audit_log.EksAuditLogTable]()
 	table.RegisterTable[*guardduty_finding.GuardDutyFinding, *guardduty_finding.GuardDutyFindingTable]()
+	table.RegisterTable[*health_event.HealthEvent, *health_event.HealthEventTable]()
+	table.RegisterTable[*inspector_finding.InspectorFinding, *inspector_finding.InspectorFindingTable]()
+	table.RegisterTable[*lambda_log.LambdaLog, *lambda_log.LambdaLogTable]()
+	table.RegisterTable[*macie_finding.MacieFinding, *macie_finding.MacieFindingTable]()
+	table.RegisterTable[*network_firewall_log.NetworkFirewallLog, *network_firewall_log.NetworkFirewallLogTable]()
 	table.RegisterTable[*nlb_access_log.NlbAccessLog, *nlb_access_log.NlbAccessLogTable]()
+	table.RegisterTable[*route53_public_dns_query_log.Route53PublicDnsQueryLog, *route53_public_dns_query_log.Route53PublicDnsQueryLogTable]()
+	table.RegisterTable[*route53_resolver_query_log.Route53ResolverQueryLog, *route53_resolver_query_log.Route53ResolverQueryLogTable]()
 	table.RegisterTable[*s3_server_access_log.S3ServerAccessLog, *s3_server_access_log.S3ServerAccessLogTable]()
-	table.RegisterTable[*alb_connection_log.AlbConnectionLog, *alb_connection_log.AlbConnectionLogTable]()
 	table.RegisterTable[*securityhub_finding.SecurityHubFinding, *securityhub_finding.SecurityHubFindingTable]()
 	table.RegisterTable[*waf_traffic_log.WafTrafficLog, *waf_traffic_log.WafTrafficLogTable]()
 
 	// register custom table
+	table.RegisterCustomTable[*api_gateway_access_log.ApiGatewayAccessLogTable]()
+	table.RegisterCustomTable[*transit_gateway_flow_log.TransitGatewayFlowLogTable]()
 	table.RegisterCustomTable[*vpc_flow_log.VpcFlowLogTable]()
 
 	// register sources
 	row_source.RegisterRowSource[*s3_bucket.AwsS3BucketSource]()
 	row_source.RegisterRowSource[*cloudwatch_log_group.AwsCloudWatchLogGroupSource]()
+	row_source.RegisterRowSource[*cloudtrail_lake.AwsCloudTrailLakeSource]()
+	row_source.RegisterRowSource[*cloudtrail_lookup_events.AwsCloudTrailLookupEventsSource]()
+	row_source.RegisterRowSource[*securityhub.AwsSecurityHubSource]()
+	row_source.RegisterRowSource[*guardduty.AwsGuardDutySource]()
 
 	// register formats
+	table.RegisterFormatPresets(api_gateway_access_log.ApiGatewayAccessLogTableFormatPresets...)
+	table.RegisterFormat[*api_gateway_access_log.ApiGatewayAccessLogTableFormat]()
+	table.RegisterFormatPresets(transit_gateway_flow_log.TransitGatewayFlowLogTableFormatPresets...)
+	table.RegisterFormat[*transit_gateway_flow_log.TransitGatewayFlowLogTableFormat]()
 	table.RegisterFormatPresets(vpc_flow_log.VPCFlowLogTableFormatPresets...)
 	table.RegisterFormat[*vpc_flow_log.VPCFlowLogTableFormat]()
 }
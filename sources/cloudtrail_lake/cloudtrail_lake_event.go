@@ -0,0 +1,151 @@
+package cloudtrail_lake
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lakeTimeFormat is the format of timestamps in CloudTrail Lake queries and query results
+const lakeTimeFormat = "2006-01-02 15:04:05.000"
+
+type lakeColumnType int
+
+const (
+	lakeColumnString lakeColumnType = iota
+	lakeColumnBool
+	lakeColumnTime
+	// lakeColumnJSON is a struct, map or array column, which is selected as a JSON string
+	lakeColumnJSON
+)
+
+type lakeColumn struct {
+	name       string
+	columnType lakeColumnType
+}
+
+// lakeEventColumns are the columns of a CloudTrail event data store which are selected by the query,
+// which correspond to the fields of a CloudTrail event record
+var lakeEventColumns = []lakeColumn{
+	{"additionalEventData", lakeColumnJSON},
+	{"apiVersion", lakeColumnString},
+	{"awsRegion", lakeColumnString},
+	{"edgeDeviceDetails", lakeColumnJSON},
+	{"errorCode", lakeColumnString},
+	{"errorMessage", lakeColumnString},
+	{"eventCategory", lakeColumnString},
+	{"eventID", lakeColumnString},
+	{"eventName", lakeColumnString},
+	{"eventSource", lakeColumnString},
+	{"eventTime", lakeColumnTime},
+	{"eventType", lakeColumnString},
+	{"eventVersion", lakeColumnString},
+	{"managementEvent", lakeColumnBool},
+	{"readOnly", lakeColumnBool},
+	{"recipientAccountId", lakeColumnString},
+	{"requestID", lakeColumnString},
+	{"requestParameters", lakeColumnJSON},
+	{"resources", lakeColumnJSON},
+	{"responseElements", lakeColumnJSON},
+	{"serviceEventDetails", lakeColumnJSON},
+	{"sessionCredentialFromConsole", lakeColumnString},
+	{"sharedEventID", lakeColumnString},
+	{"sourceIPAddress", lakeColumnString},
+	{"tlsDetails", lakeColumnJSON},
+	{"userAgent", lakeColumnString},
+	{"userIdentity", lakeColumnJSON},
+	{"vpcEndpointId", lakeColumnString},
+}
+
+// buildQuery builds the CloudTrail Lake SQL query to select the events in the given time range,
+// converting struct, map and array columns to JSON so the results can be rebuilt into CloudTrail event records
+func buildQuery(eventDataStoreId string, from, to time.Time, filter *string) string {
+	columns := make([]string, len(lakeEventColumns))
+	for i, c := range lakeEventColumns {
+		if c.columnType == lakeColumnJSON {
+			columns[i] = fmt.Sprintf("json_format(CAST(%s AS JSON)) AS %s", c.name, c.name)
+		} else {
+			columns[i] = c.name
+		}
+	}
+
+	where := fmt.Sprintf("eventTime >= '%s' AND eventTime < '%s'", from.UTC().Format(lakeTimeFormat), to.UTC().Format(lakeTimeFormat))
+	if filter != nil && *filter != "" {
+		where = fmt.Sprintf("%s AND (%s)", where, *filter)
+	}
+
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY eventTime, eventID", strings.Join(columns, ", "), eventDataStoreId, where)
+}
+
+// lakeEvent is a CloudTrail event rebuilt from a row of CloudTrail Lake query results
+type lakeEvent struct {
+	EventID   string
+	EventTime time.Time
+	// Data is the JSON serialised CloudTrail event record
+	Data []byte
+}
+
+// newLakeEvent rebuilds a CloudTrail event record from a row of query results.
+// Each row is a list of single entry maps of column name to the string value of the column.
+func newLakeEvent(row []map[string]string) (*lakeEvent, error) {
+	columnTypes := make(map[string]lakeColumnType, len(lakeEventColumns))
+	for _, c := range lakeEventColumns {
+		columnTypes[c.name] = c.columnType
+	}
+
+	event := &lakeEvent{}
+	record := make(map[string]any)
+	for _, column := range row {
+		for name, value := range column {
+			if value == "" {
+				continue
+			}
+			switch columnTypes[name] {
+			case lakeColumnBool:
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+				}
+				record[name] = b
+			case lakeColumnTime:
+				t, err := time.Parse(lakeTimeFormat, value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+				}
+				record[name] = t.UTC().Format(time.RFC3339)
+				if name == "eventTime" {
+					event.EventTime = t.UTC()
+				}
+			case lakeColumnJSON:
+				if value == "null" {
+					continue
+				}
+				if !json.Valid([]byte(value)) {
+					return nil, fmt.Errorf("invalid JSON value for %s", name)
+				}
+				record[name] = json.RawMessage(value)
+			default:
+				record[name] = value
+				if name == "eventID" {
+					event.EventID = value
+				}
+			}
+		}
+	}
+
+	if event.EventID == "" {
+		return nil, fmt.Errorf("query result row has no eventID")
+	}
+	if event.EventTime.IsZero() {
+		return nil, fmt.Errorf("query result row for event %s has no eventTime", event.EventID)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event %s: %w", event.EventID, err)
+	}
+	event.Data = data
+	return event, nil
+}
@@ -0,0 +1,103 @@
+package cloudtrail_lake
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewLakeEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      []map[string]string
+		expected map[string]any
+		wantErr  bool
+	}{
+		{
+			name: "event with scalar, bool, time and JSON columns",
+			row: []map[string]string{
+				{"eventID": "b1a2c3d4-0000-1111-2222-333344445555"},
+				{"eventTime": "2024-03-05 10:20:30.000"},
+				{"eventName": "CreateUser"},
+				{"readOnly": "false"},
+				{"userIdentity": `{"type":"IAMUser","userName":"alice"}`},
+				{"requestParameters": `{"userName":"bob"}`},
+				{"responseElements": "null"},
+				{"errorCode": ""},
+			},
+			expected: map[string]any{
+				"eventID":           "b1a2c3d4-0000-1111-2222-333344445555",
+				"eventTime":         "2024-03-05T10:20:30Z",
+				"eventName":         "CreateUser",
+				"readOnly":          false,
+				"userIdentity":      map[string]any{"type": "IAMUser", "userName": "alice"},
+				"requestParameters": map[string]any{"userName": "bob"},
+			},
+		},
+		{
+			name: "missing eventTime",
+			row: []map[string]string{
+				{"eventID": "b1a2c3d4-0000-1111-2222-333344445555"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid JSON column",
+			row: []map[string]string{
+				{"eventID": "b1a2c3d4-0000-1111-2222-333344445555"},
+				{"eventTime": "2024-03-05 10:20:30.000"},
+				{"userIdentity": "{type=IAMUser}"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := newLakeEvent(tt.row)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !event.EventTime.Equal(time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)) {
+				t.Errorf("unexpected event time: %v", event.EventTime)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(event.Data, &got); err != nil {
+				t.Fatalf("failed to unmarshal event data: %v", err)
+			}
+			gotJSON, _ := json.Marshal(got)
+			expectedJSON, _ := json.Marshal(tt.expected)
+			if string(gotJSON) != string(expectedJSON) {
+				t.Errorf("event data mismatch\n got: %s\nwant: %s", gotJSON, expectedJSON)
+			}
+		})
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	from := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)
+	filter := "eventSource = 'iam.amazonaws.com'"
+
+	query := buildQuery("my-eds-id", from, to, &filter)
+
+	for _, want := range []string{
+		"json_format(CAST(userIdentity AS JSON)) AS userIdentity",
+		"FROM my-eds-id",
+		"eventTime >= '2024-03-05 00:00:00.000' AND eventTime < '2024-03-06 00:00:00.000' AND (eventSource = 'iam.amazonaws.com')",
+		"ORDER BY eventTime, eventID",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query does not contain %q:\n%s", want, query)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cloudtrail_lake
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// IsQueryResultsCSV returns whether the data is CloudTrail Lake query results delivered to S3,
+// i.e. the first line is a CSV header row which includes the eventID column.
+func IsQueryResultsCSV(data []byte) bool {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	header, err := csv.NewReader(bytes.NewReader(bytes.TrimRight(line, "\r"))).Read()
+	if err != nil {
+		return false
+	}
+	return slices.Contains(header, "eventID")
+}
+
+// EventsFromQueryResultsCSV rebuilds the CloudTrail event records from the query results which CloudTrail Lake
+// delivers to S3, if the query was run with delivery_s3_uri. The results are a CSV file with a header row of
+// the selected column names, so must be the results of a query selecting the columns used by this source.
+// Returns the JSON serialised event records.
+func EventsFromQueryResultsCSV(data []byte) ([][]byte, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading query results header: %w", err)
+	}
+	// the event ID and time are required to rebuild the event
+	if !slices.Contains(header, "eventID") || !slices.Contains(header, "eventTime") {
+		return nil, fmt.Errorf("query results must include the eventID and eventTime columns")
+	}
+
+	var res [][]byte
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading query results: %w", err)
+		}
+
+		// convert to the same form as the rows returned by GetQueryResults
+		row := make([]map[string]string, 0, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				row = append(row, map[string]string{name: record[i]})
+			}
+		}
+
+		event, err := newLakeEvent(row)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, event.Data)
+	}
+	return res, nil
+}
@@ -0,0 +1,125 @@
+package cloudtrail_lake
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEventsFromQueryResultsCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []map[string]any
+		wantErr  bool
+	}{
+		{
+			name: "query results",
+			data: "eventID,eventTime,eventName,readOnly,userIdentity\n" +
+				`b1a2c3d4-0000-1111-2222-333344445555,2024-03-05 10:20:30.000,CreateUser,false,"{""type"":""IAMUser"",""userName"":""alice""}"` + "\n" +
+				"c1a2c3d4-0000-1111-2222-333344445555,2024-03-05 10:21:00.000,ListUsers,true,\n",
+			expected: []map[string]any{
+				{
+					"eventID":      "b1a2c3d4-0000-1111-2222-333344445555",
+					"eventTime":    "2024-03-05T10:20:30Z",
+					"eventName":    "CreateUser",
+					"readOnly":     false,
+					"userIdentity": map[string]any{"type": "IAMUser", "userName": "alice"},
+				},
+				{
+					"eventID":   "c1a2c3d4-0000-1111-2222-333344445555",
+					"eventTime": "2024-03-05T10:21:00Z",
+					"eventName": "ListUsers",
+					"readOnly":  true,
+				},
+			},
+		},
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name:    "missing eventID column",
+			data:    "eventTime,eventName\n2024-03-05 10:20:30.000,CreateUser\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid row",
+			data:    "eventID,eventTime\nb1a2c3d4-0000-1111-2222-333344445555,yesterday\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := EventsFromQueryResultsCSV([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != len(tt.expected) {
+				t.Fatalf("got %d events, want %d", len(events), len(tt.expected))
+			}
+			for i, event := range events {
+				var got map[string]any
+				if err := json.Unmarshal(event, &got); err != nil {
+					t.Fatalf("invalid event JSON: %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.expected[i]) {
+					t.Errorf("event %d = %v, want %v", i, got, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIsQueryResultsCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{
+			name: "query results",
+			data: "eventID,eventTime,eventName\nb1a2c3d4-0000-1111-2222-333344445555,2024-03-05 10:20:30.000,CreateUser\n",
+			want: true,
+		},
+		{
+			name: "query results with CRLF line endings",
+			data: "eventTime,eventID\r\n2024-03-05 10:20:30.000,b1a2c3d4-0000-1111-2222-333344445555\r\n",
+			want: true,
+		},
+		{
+			name: "CloudTrail log file",
+			data: `{"Records":[{"eventID":"b1a2c3d4-0000-1111-2222-333344445555"}]}`,
+		},
+		{
+			name: "JSON array",
+			data: `[{"eventID":"b1a2c3d4-0000-1111-2222-333344445555"}]`,
+		},
+		{
+			name: "CSV without eventID column",
+			data: "eventTime,eventName\n2024-03-05 10:20:30.000,CreateUser\n",
+		},
+		{
+			name: "byte order mark",
+			data: "\ufeff{\"Records\":[]}",
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsQueryResultsCSV([]byte(tt.data)); got != tt.want {
+				t.Errorf("IsQueryResultsCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Package cloudtrail_lake provides functionality to collect events from AWS CloudTrail Lake event data stores
+//
+// This package runs a CloudTrail Lake SQL query over an event data store for the collection time range,
+// and rebuilds each result row into a CloudTrail event record, supporting incremental collection.
+package cloudtrail_lake
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	ctTypes "github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	"github.com/turbot/tailpipe-plugin-aws/config"
+	"github.com/turbot/tailpipe-plugin-sdk/collection_state"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+const (
+	// AwsCloudTrailLakeSourceIdentifier is the unique identifier for the CloudTrail Lake source
+	AwsCloudTrailLakeSourceIdentifier = "aws_cloudtrail_lake"
+
+	// queryPollInterval is the interval between checks of the status of a running query
+	queryPollInterval = 2 * time.Second
+	// maxQueryResults is the maximum number of rows returned by each GetQueryResults call
+	maxQueryResults = 1000
+)
+
+// AwsCloudTrailLakeSource is responsible for collecting events from a CloudTrail Lake event data store.
+// It implements the RowSource interface, and tracks the collected events in a time range collection state.
+type AwsCloudTrailLakeSource struct {
+	// Embeds the base RowSourceImpl with CloudTrail Lake-specific config and AWS connection.
+	row_source.RowSourceImpl[*AwsCloudTrailLakeSourceConfig, *config.AwsConnection]
+
+	// client is the AWS CloudTrail client used for API calls.
+	client *cloudtrail.Client
+	// errorList accumulates errors encountered during collection for reporting.
+	errorList []error
+}
+
+// Init sets up the CloudTrail Lake source with the provided parameters and options.
+func (s *AwsCloudTrailLakeSource) Init(ctx context.Context, params *row_source.RowSourceParams, opts ...row_source.RowSourceOption) error {
+	// Set up the collection state constructor
+	s.NewCollectionStateFunc = collection_state.NewTimeRangeCollectionState
+
+	// CloudTrail Lake event times have millisecond precision
+	s.RowSourceImpl.GetGranularityFunc = s.getGranularity
+
+	// Initialize the base implementation
+	if err := s.RowSourceImpl.Init(ctx, params, opts...); err != nil {
+		return err
+	}
+
+	// Initialize AWS CloudTrail client
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.client = client
+	s.errorList = []error{}
+
+	return nil
+}
+
+// getGranularity returns the granularity for this source type, which is set to 1 millisecond.
+func (s *AwsCloudTrailLakeSource) getGranularity() time.Duration {
+	return time.Millisecond
+}
+
+// Identifier returns the unique identifier for this source type, used in the plugin system.
+func (s *AwsCloudTrailLakeSource) Identifier() string {
+	return AwsCloudTrailLakeSourceIdentifier
+}
+
+// Collect runs a CloudTrail Lake query for the events in the collection time range, waits for it to complete,
+// and pages through the results, rebuilding each row into a CloudTrail event record and forwarding it for processing.
+//
+// Events are returned in time order, so the collection state is used to skip events which were
+// collected by a previous collection.
+func (s *AwsCloudTrailLakeSource) Collect(ctx context.Context) error {
+	from := s.CollectionTimeRange.StartTime()
+	to := s.CollectionTimeRange.EndTime()
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	queryId, err := s.startQuery(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Started CloudTrail Lake query", "query_id", queryId, "event_data_store", s.Config.EventDataStore, "from", from, "to", to)
+
+	sourceEnrichmentFields := &schema.SourceEnrichment{
+		CommonFields: schema.CommonFields{
+			TpSourceType:     AwsCloudTrailLakeSourceIdentifier,
+			TpSourceName:     &s.Config.EventDataStore,
+			TpSourceLocation: &queryId,
+		},
+	}
+
+	input := &cloudtrail.GetQueryResultsInput{
+		QueryId:         &queryId,
+		MaxQueryResults: aws.Int32(maxQueryResults),
+	}
+	for {
+		output, err := s.getQueryResults(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		for _, resultRow := range output.QueryResultRows {
+			event, err := newLakeEvent(resultRow)
+			if err != nil {
+				s.errorList = append(s.errorList, fmt.Errorf("failed to read query result row: %w", err))
+				continue
+			}
+
+			// Skip already collected events based on state
+			if !s.CollectionState.ShouldCollect(event.EventID, event.EventTime) {
+				slog.Debug("Skipping already collected event", "event_id", event.EventID, "timestamp", event.EventTime.Format(time.RFC3339))
+				continue
+			}
+
+			row := &types.RowData{
+				Data:             event.Data,
+				SourceEnrichment: sourceEnrichmentFields,
+			}
+
+			// Update collection state with the processed event
+			if err := s.CollectionState.OnCollected(event.EventID, event.EventTime); err != nil {
+				s.errorList = append(s.errorList, fmt.Errorf("failed to update collection state for event %s: %w", event.EventID, err))
+				continue
+			}
+
+			// Send the row for processing
+			if err := s.OnRow(ctx, row); err != nil {
+				s.errorList = append(s.errorList, fmt.Errorf("error processing event %s: %w", event.EventID, err))
+				continue
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	// Return collected errors if any
+	if len(s.errorList) > 0 {
+		return fmt.Errorf("encountered %d errors during CloudTrail Lake collection: %v", len(s.errorList), s.errorList)
+	}
+
+	return nil
+}
+
+// startQuery starts the query for the events in the given time range, returning the ID of the query
+func (s *AwsCloudTrailLakeSource) startQuery(ctx context.Context, from, to time.Time) (string, error) {
+	queryStatement := buildQuery(s.Config.eventDataStoreId(), from, to, s.Config.Filter)
+	slog.Debug("Starting CloudTrail Lake query", "query", queryStatement)
+
+	output, err := s.client.StartQuery(ctx, &cloudtrail.StartQueryInput{
+		QueryStatement: &queryStatement,
+		DeliveryS3Uri:  s.Config.DeliveryS3Uri,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start CloudTrail Lake query, %w", err)
+	}
+	if output.QueryId == nil {
+		return "", fmt.Errorf("failed to start CloudTrail Lake query, no query ID returned")
+	}
+	return *output.QueryId, nil
+}
+
+// getQueryResults retrieves a page of results for the query, waiting for the query to finish if it is
+// queued or running. Returns an error if the query failed, was cancelled or timed out.
+func (s *AwsCloudTrailLakeSource) getQueryResults(ctx context.Context, input *cloudtrail.GetQueryResultsInput) (*cloudtrail.GetQueryResultsOutput, error) {
+	for {
+		output, err := s.client.GetQueryResults(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CloudTrail Lake query results, %w", err)
+		}
+
+		switch output.QueryStatus {
+		case ctTypes.QueryStatusFinished:
+			return output, nil
+		case ctTypes.QueryStatusQueued, ctTypes.QueryStatusRunning:
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(queryPollInterval):
+			}
+		default:
+			message := ""
+			if output.ErrorMessage != nil {
+				message = *output.ErrorMessage
+			}
+			return nil, fmt.Errorf("CloudTrail Lake query %s %s: %s", *input.QueryId, output.QueryStatus, message)
+		}
+	}
+}
+
+// getClient initializes and returns an AWS CloudTrail client for the configured region.
+// Returns an error if the client cannot be created.
+func (s *AwsCloudTrailLakeSource) getClient(ctx context.Context) (*cloudtrail.Client, error) {
+	cfg, err := s.Connection.GetClientConfiguration(ctx, s.Config.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client configuration, %w", err)
+	}
+
+	return cloudtrail.NewFromConfig(*cfg), nil
+}
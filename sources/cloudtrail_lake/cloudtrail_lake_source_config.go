@@ -0,0 +1,52 @@
+package cloudtrail_lake
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AwsCloudTrailLakeSourceConfig defines the configuration parameters for collecting events from a CloudTrail Lake event data store.
+// It specifies which event data store to query, an optional filter to apply to the query,
+// and optionally an S3 location to deliver a copy of the query results to.
+type AwsCloudTrailLakeSourceConfig struct {
+	// EventDataStore is the ARN or ID of the CloudTrail Lake event data store to query (required)
+	EventDataStore string `hcl:"event_data_store"`
+	// Filter is an optional SQL condition added to the WHERE clause of the query,
+	// e.g. "eventSource = 'iam.amazonaws.com'"
+	Filter *string `hcl:"filter,optional"`
+	// DeliveryS3Uri is an optional S3 URI that CloudTrail delivers the query results to,
+	// e.g. "s3://my-bucket/cloudtrail-lake-results"
+	DeliveryS3Uri *string `hcl:"delivery_s3_uri,optional"`
+	// Region specifies the AWS region where the event data store exists
+	Region *string `hcl:"region"`
+}
+
+// Validate checks if the configuration is valid.
+func (c *AwsCloudTrailLakeSourceConfig) Validate() error {
+	if c.EventDataStore == "" {
+		return fmt.Errorf("event_data_store is required and cannot be empty")
+	}
+	if c.Region == nil {
+		return fmt.Errorf("region is required and cannot be empty")
+	}
+	if c.DeliveryS3Uri != nil && !strings.HasPrefix(*c.DeliveryS3Uri, "s3://") {
+		return fmt.Errorf("delivery_s3_uri must be an S3 URI, e.g. s3://my-bucket/prefix")
+	}
+	return nil
+}
+
+// Identifier returns the unique identifier for this source type.
+func (c *AwsCloudTrailLakeSourceConfig) Identifier() string {
+	return AwsCloudTrailLakeSourceIdentifier
+}
+
+// eventDataStoreId returns the ID of the event data store, which is used in the FROM clause of the query
+// (the ID is the final element of the event data store ARN)
+func (c *AwsCloudTrailLakeSourceConfig) eventDataStoreId() string {
+	if strings.HasPrefix(c.EventDataStore, "arn:") {
+		if i := strings.LastIndex(c.EventDataStore, "/"); i != -1 {
+			return c.EventDataStore[i+1:]
+		}
+	}
+	return c.EventDataStore
+}
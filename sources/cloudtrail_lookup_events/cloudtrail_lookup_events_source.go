@@ -0,0 +1,178 @@
+// Package cloudtrail_lookup_events provides functionality to collect the CloudTrail event history of an account
+//
+// This package uses the CloudTrail LookupEvents API to collect the last 90 days of management events for each
+// configured region, for accounts which do not have a trail, supporting incremental collection.
+package cloudtrail_lookup_events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	ctTypes "github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	"golang.org/x/time/rate"
+
+	"github.com/turbot/tailpipe-plugin-aws/config"
+	"github.com/turbot/tailpipe-plugin-aws/sources/windowed_collection"
+	"github.com/turbot/tailpipe-plugin-sdk/collection_state"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+const (
+	// AwsCloudTrailLookupEventsSourceIdentifier is the unique identifier for the CloudTrail LookupEvents source
+	AwsCloudTrailLookupEventsSourceIdentifier = "aws_cloudtrail_lookup_events"
+
+	// eventHistoryPeriod is the period of event history available from the LookupEvents API
+	eventHistoryPeriod = 90 * 24 * time.Hour
+	// lookupWindow is the duration of the time windows the collection time range is split into.
+	// The events of each window are collected for all regions and sorted before being processed.
+	lookupWindow = time.Hour
+	// lookupEventsRateLimit is the LookupEvents API limit of 2 requests per second, per account, per region
+	lookupEventsRateLimit = 2
+	// maxLookupResults is the maximum number of events returned by each LookupEvents call
+	maxLookupResults = 50
+)
+
+// AwsCloudTrailLookupEventsSource is responsible for collecting the CloudTrail event history of an account
+// using the LookupEvents API. It implements the RowSource interface, and tracks the collected events in a
+// time range collection state.
+type AwsCloudTrailLookupEventsSource struct {
+	// Embeds the base RowSourceImpl with LookupEvents-specific config and AWS connection.
+	row_source.RowSourceImpl[*AwsCloudTrailLookupEventsSourceConfig, *config.AwsConnection]
+
+	// collector holds the CloudTrail client of each region, and collects the events of all regions in time windows.
+	collector windowed_collection.Collector[*cloudtrail.Client, ctTypes.Event]
+	// limiters limit the rate of LookupEvents calls for each region to the API limit.
+	limiters map[string]*rate.Limiter
+}
+
+// Init sets up the LookupEvents source with the provided parameters and options,
+// creating a CloudTrail client and rate limiter for each region.
+func (s *AwsCloudTrailLookupEventsSource) Init(ctx context.Context, params *row_source.RowSourceParams, opts ...row_source.RowSourceOption) error {
+	// Set up the collection state constructor
+	s.NewCollectionStateFunc = collection_state.NewTimeRangeCollectionState
+
+	// CloudTrail event times have second precision
+	s.RowSourceImpl.GetGranularityFunc = s.getGranularity
+
+	// Initialize the base implementation
+	if err := s.RowSourceImpl.Init(ctx, params, opts...); err != nil {
+		return err
+	}
+
+	return s.initClients(ctx)
+}
+
+// getGranularity returns the granularity for this source type, which is set to 1 second.
+func (s *AwsCloudTrailLookupEventsSource) getGranularity() time.Duration {
+	return time.Second
+}
+
+// Identifier returns the unique identifier for this source type, used in the plugin system.
+func (s *AwsCloudTrailLookupEventsSource) Identifier() string {
+	return AwsCloudTrailLookupEventsSourceIdentifier
+}
+
+// Collect retrieves the CloudTrail event history for the collection time range.
+//
+// The LookupEvents API only returns events from the last 90 days, so the start of the time range is limited to
+// this period. The time range is split into windows, and for each window:
+//  1. The events for each region are retrieved, paginating and limiting requests to the API rate limit.
+//  2. The events for all regions are sorted chronologically.
+//  3. Events already collected are skipped based on the collection state.
+//  4. The CloudTrail event record of each new event is forwarded for processing.
+func (s *AwsCloudTrailLookupEventsSource) Collect(ctx context.Context) error {
+	from := s.CollectionTimeRange.StartTime()
+	to := s.CollectionTimeRange.EndTime()
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if earliest := time.Now().Add(-eventHistoryPeriod); from.Before(earliest) {
+		slog.Info("Limiting collection to the 90 days of CloudTrail event history", "from", from, "earliest", earliest)
+		from = earliest
+	}
+
+	if err := s.collector.Collect(ctx, s.CollectionState, from, to, lookupWindow, s.lookupEvents, s.processEvent); err != nil {
+		return fmt.Errorf("failed to lookup events, %w", err)
+	}
+
+	return s.collector.Error("CloudTrail event history")
+}
+
+// processEvent forwards the CloudTrail event record of an event for processing
+func (s *AwsCloudTrailLookupEventsSource) processEvent(ctx context.Context, e windowed_collection.Item[ctTypes.Event]) error {
+	region := e.Region
+	row := &types.RowData{
+		Data: []byte(*e.Value.CloudTrailEvent),
+		SourceEnrichment: &schema.SourceEnrichment{
+			CommonFields: schema.CommonFields{
+				TpSourceType:     AwsCloudTrailLookupEventsSourceIdentifier,
+				TpSourceLocation: &region,
+			},
+		},
+	}
+
+	return s.OnRow(ctx, row)
+}
+
+// lookupEvents retrieves all events for a region in the given time range, handling pagination.
+// Requests are limited to the LookupEvents API rate limit for the region.
+// The end time of LookupEvents is inclusive, so events at the end time are returned, and skipped by the collector.
+func (s *AwsCloudTrailLookupEventsSource) lookupEvents(ctx context.Context, region string, from, to time.Time) ([]windowed_collection.Item[ctTypes.Event], error) {
+	var events []windowed_collection.Item[ctTypes.Event]
+
+	input := &cloudtrail.LookupEventsInput{
+		StartTime:  aws.Time(from),
+		EndTime:    aws.Time(to),
+		MaxResults: aws.Int32(maxLookupResults),
+	}
+
+	paginator := cloudtrail.NewLookupEventsPaginator(s.collector.Clients[region], input)
+	for paginator.HasMorePages() {
+		if err := s.limiters[region].Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range output.Events {
+			if event.EventId == nil || event.EventTime == nil {
+				s.collector.Errors = append(s.collector.Errors, fmt.Errorf("skipping event with no ID or time in region %s", region))
+				continue
+			}
+			if event.CloudTrailEvent == nil || *event.CloudTrailEvent == "" {
+				s.collector.Errors = append(s.collector.Errors, fmt.Errorf("empty CloudTrail event for event %s in region %s", *event.EventId, region))
+				continue
+			}
+			events = append(events, windowed_collection.Item[ctTypes.Event]{Region: region, Id: *event.EventId, Time: *event.EventTime, Value: event})
+		}
+	}
+
+	return events, nil
+}
+
+// initClients initializes an AWS CloudTrail client and rate limiter for each configured region.
+// If no regions are configured, the region of the connection is used.
+func (s *AwsCloudTrailLookupEventsSource) initClients(ctx context.Context) error {
+	newClient := func(cfg aws.Config) *cloudtrail.Client {
+		return cloudtrail.NewFromConfig(cfg)
+	}
+	if err := s.collector.Init(ctx, s.Connection, s.Config.Regions, newClient); err != nil {
+		return err
+	}
+
+	s.limiters = make(map[string]*rate.Limiter)
+	for _, region := range s.collector.Regions {
+		s.limiters[region] = rate.NewLimiter(rate.Limit(lookupEventsRateLimit), 1)
+	}
+
+	return nil
+}
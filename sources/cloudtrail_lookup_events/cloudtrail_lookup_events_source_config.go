@@ -0,0 +1,26 @@
+package cloudtrail_lookup_events
+
+import "fmt"
+
+// AwsCloudTrailLookupEventsSourceConfig defines the configuration parameters for collecting the CloudTrail event history
+// of an account using the LookupEvents API.
+type AwsCloudTrailLookupEventsSourceConfig struct {
+	// Regions are the AWS regions to collect the event history of.
+	// If not specified, the region of the connection is used.
+	Regions []string `hcl:"regions,optional"`
+}
+
+// Validate checks if the configuration is valid.
+func (c *AwsCloudTrailLookupEventsSourceConfig) Validate() error {
+	for _, region := range c.Regions {
+		if region == "" {
+			return fmt.Errorf("regions cannot contain an empty region")
+		}
+	}
+	return nil
+}
+
+// Identifier returns the unique identifier for this source type.
+func (c *AwsCloudTrailLookupEventsSourceConfig) Identifier() string {
+	return AwsCloudTrailLookupEventsSourceIdentifier
+}
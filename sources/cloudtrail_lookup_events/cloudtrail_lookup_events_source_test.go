@@ -0,0 +1,79 @@
+package cloudtrail_lookup_events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"golang.org/x/time/rate"
+)
+
+// lookupEventsHTTPClient records the LookupEvents request, responding with a single page of events
+type lookupEventsHTTPClient struct {
+	request map[string]any
+	events  []map[string]any
+}
+
+func (c *lookupEventsHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if err := json.NewDecoder(req.Body).Decode(&c.request); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(map[string]any{"Events": c.events})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func TestLookupEvents(t *testing.T) {
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(lookupWindow)
+
+	httpClient := &lookupEventsHTTPClient{
+		events: []map[string]any{
+			{"EventId": "a", "EventTime": from.Unix(), "CloudTrailEvent": `{"eventID":"a"}`},
+			{"EventTime": from.Unix(), "CloudTrailEvent": `{}`},
+			{"EventId": "c", "EventTime": from.Unix()},
+		},
+	}
+
+	s := &AwsCloudTrailLookupEventsSource{}
+	s.collector.Clients = map[string]*cloudtrail.Client{
+		"us-east-1": cloudtrail.New(cloudtrail.Options{
+			Region:      "us-east-1",
+			Credentials: aws.AnonymousCredentials{},
+			HTTPClient:  httpClient,
+		}),
+	}
+	s.limiters = map[string]*rate.Limiter{"us-east-1": rate.NewLimiter(rate.Inf, 1)}
+
+	events, err := s.lookupEvents(context.Background(), "us-east-1", from, to)
+	if err != nil {
+		t.Fatalf("lookupEvents() error = %v", err)
+	}
+
+	if httpClient.request["StartTime"] != float64(from.Unix()) || httpClient.request["EndTime"] != float64(to.Unix()) {
+		t.Errorf("request time range = %v - %v, want %d - %d", httpClient.request["StartTime"], httpClient.request["EndTime"], from.Unix(), to.Unix())
+	}
+
+	// events with no ID or CloudTrail event record are skipped
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	if e := events[0]; e.Id != "a" || e.Region != "us-east-1" || !e.Time.Equal(from) {
+		t.Errorf("event = %s %s %s, want a us-east-1 %s", e.Id, e.Region, e.Time, from)
+	}
+	if len(s.collector.Errors) != 2 {
+		t.Errorf("errors = %v, want 2 errors", s.collector.Errors)
+	}
+}
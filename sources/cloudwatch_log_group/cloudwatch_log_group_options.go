@@ -0,0 +1,29 @@
+package cloudwatch_log_group
+
+import (
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+)
+
+// WithDefaultLogStreamNames is used by a table to specify the log stream name patterns to collect
+// if log_stream_names is not configured, e.g. when a log group contains streams for several log types.
+func WithDefaultLogStreamNames(logStreamNames ...string) row_source.RowSourceOption {
+	return func(r row_source.RowSource) error {
+		if s, ok := r.(*AwsCloudWatchLogGroupSource); ok {
+			s.SetDefaultLogStreamNames(logStreamNames)
+		}
+		return nil
+	}
+}
+
+// WithLogEventFilter is used by a table to skip log events it does not collect, without raising an error,
+// e.g. events of other types when a log group contains several log types.
+func WithLogEventFilter(filter func(cwTypes.FilteredLogEvent) bool) row_source.RowSourceOption {
+	return func(r row_source.RowSource) error {
+		if s, ok := r.(*AwsCloudWatchLogGroupSource); ok {
+			s.SetLogEventFilter(filter)
+		}
+		return nil
+	}
+}
@@ -36,6 +36,11 @@ type AwsCloudWatchLogGroupSource struct {
 	client *cloudwatchlogs.Client
 	// errorList accumulates errors encountered during collection for reporting.
 	errorList []error
+	// defaultLogStreamNames are the log stream name patterns used if log_stream_names is not configured,
+	// set by the table using WithDefaultLogStreamNames.
+	defaultLogStreamNames []string
+	// logEventFilter, if set by the table using WithLogEventFilter, determines whether each log event is collected.
+	logEventFilter func(cwTypes.FilteredLogEvent) bool
 	// state tracks progress and supports incremental collection across log streams.
 	//state *CloudWatchLogGroupCollectionState
 }
@@ -81,6 +86,16 @@ func (s *AwsCloudWatchLogGroupSource) Identifier() string {
 	return AwsCloudwatchLogGroupSourceIdentifier
 }
 
+// SetDefaultLogStreamNames sets the log stream name patterns to collect if log_stream_names is not configured.
+func (s *AwsCloudWatchLogGroupSource) SetDefaultLogStreamNames(logStreamNames []string) {
+	s.defaultLogStreamNames = logStreamNames
+}
+
+// SetLogEventFilter sets the function used to determine whether each log event is collected.
+func (s *AwsCloudWatchLogGroupSource) SetLogEventFilter(filter func(cwTypes.FilteredLogEvent) bool) {
+	s.logEventFilter = filter
+}
+
 // matchesAnyPattern returns true if the target string matches any of the provided patterns (supports wildcards).
 func matchesAnyPattern(target string, patterns []string) bool {
 	for _, pattern := range patterns {
@@ -121,9 +136,13 @@ func (s *AwsCloudWatchLogGroupSource) Collect(ctx context.Context) error {
 		"log_group", s.Config.LogGroupName)
 
 	// Filter out the log streams that are not in the list of log stream names
-	if len(s.Config.LogStreamNames) > 0 {
+	// (if no log stream names are configured, use the table defaults, if any)
+	logStreamNames := s.Config.LogStreamNames
+	if len(logStreamNames) == 0 {
+		logStreamNames = s.defaultLogStreamNames
+	}
+	if len(logStreamNames) > 0 {
 		filteredLogStreamCollection := []cwTypes.LogStream{}
-		logStreamNames := s.Config.LogStreamNames
 
 		for _, ls := range logStreamCollection {
 			if ls.LogStreamName == nil {
@@ -222,6 +241,13 @@ func (s *AwsCloudWatchLogGroupSource) Collect(ctx context.Context) error {
 				continue
 			}
 
+			// Skip events the table does not collect, e.g. other log types in a shared log group
+			// (these are still marked as collected, so they are not fetched again)
+			if s.logEventFilter != nil && !s.logEventFilter(event) {
+				slog.Debug("Skipping filtered event", "stream", *event.LogStreamName, "timestamp", timestamp.Format(time.RFC3339))
+				continue
+			}
+
 			// Send the row for processing
 			if err := s.OnRow(ctx, row); err != nil {
 				s.errorList = append(s.errorList, fmt.Errorf("error processing row in stream %s: %w", *event.LogStreamName, err))
@@ -0,0 +1,51 @@
+package cloudwatch_log_group
+
+import "testing"
+
+func TestMatchesAnyPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		patterns []string
+		want     bool
+	}{
+		{
+			name:     "lambda version",
+			target:   "2024/01/01/[$LATEST]0123456789abcdef0123456789abcdef",
+			patterns: []string{`*/*/*/\[$LATEST\]*`},
+			want:     true,
+		},
+		{
+			name:     "lambda other version",
+			target:   "2024/01/01/[12]0123456789abcdef0123456789abcdef",
+			patterns: []string{`*/*/*/\[$LATEST\]*`},
+			want:     false,
+		},
+		{
+			name:     "wildcard does not match separator",
+			target:   "2024/01/01/[$LATEST]0123456789abcdef0123456789abcdef",
+			patterns: []string{`*\[$LATEST\]*`},
+			want:     false,
+		},
+		{
+			name:     "any pattern",
+			target:   "cloudtrail-management[$LATEST]/456789012345_CloudTrail_us-east-1",
+			patterns: []string{"other*", `cloudtrail-management\[$LATEST\]/456789012345*`},
+			want:     true,
+		},
+		{
+			name:     "invalid pattern",
+			target:   "stream",
+			patterns: []string{"["},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAnyPattern(tt.target, tt.patterns); got != tt.want {
+				t.Errorf("matchesAnyPattern(%q, %q) = %v, want %v", tt.target, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Package guardduty provides functionality to collect findings from Amazon GuardDuty
+//
+// This package uses the GuardDuty ListDetectors, ListFindings and GetFindings APIs to collect the findings
+// updated in the collection time range for the detectors of each configured region, supporting incremental collection.
+package guardduty
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/guardduty"
+	"github.com/aws/aws-sdk-go-v2/service/guardduty/types"
+
+	"github.com/turbot/tailpipe-plugin-aws/config"
+	"github.com/turbot/tailpipe-plugin-aws/sources/windowed_collection"
+	"github.com/turbot/tailpipe-plugin-sdk/collection_state"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	rowTypes "github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+const (
+	// AwsGuardDutySourceIdentifier is the unique identifier for the GuardDuty source
+	AwsGuardDutySourceIdentifier = "aws_guardduty"
+
+	// findingRetentionPeriod is the period GuardDuty retains findings for
+	findingRetentionPeriod = 90 * 24 * time.Hour
+	// findingsWindow is the duration of the time windows the collection time range is split into.
+	// The findings updated in each window are collected for all regions and sorted before being processed.
+	findingsWindow = 24 * time.Hour
+	// maxFindingIds is the maximum number of findings returned by each ListFindings call,
+	// which is also the maximum number of findings which can be requested by each GetFindings call
+	maxFindingIds = 50
+)
+
+// AwsGuardDutySource is responsible for collecting findings from the GuardDuty API.
+// It implements the RowSource interface, and tracks the collected findings by their UpdatedAt time
+// in a time range collection state.
+type AwsGuardDutySource struct {
+	// Embeds the base RowSourceImpl with GuardDuty-specific config and AWS connection.
+	row_source.RowSourceImpl[*AwsGuardDutySourceConfig, *config.AwsConnection]
+
+	// collector holds the GuardDuty client of each region, and collects the findings of all regions in time windows.
+	collector windowed_collection.Collector[*guardduty.Client, types.Finding]
+	// detectorIds are the IDs of the detectors to collect the findings of, keyed by region.
+	detectorIds map[string][]string
+}
+
+// Init sets up the GuardDuty source with the provided parameters and options,
+// creating a GuardDuty client for each region.
+func (s *AwsGuardDutySource) Init(ctx context.Context, params *row_source.RowSourceParams, opts ...row_source.RowSourceOption) error {
+	// Set up the collection state constructor
+	s.NewCollectionStateFunc = collection_state.NewTimeRangeCollectionState
+
+	// GuardDuty timestamps have millisecond precision
+	s.RowSourceImpl.GetGranularityFunc = s.getGranularity
+
+	// Initialize the base implementation
+	if err := s.RowSourceImpl.Init(ctx, params, opts...); err != nil {
+		return err
+	}
+
+	return s.initClients(ctx)
+}
+
+// getGranularity returns the granularity for this source type, which is set to 1 millisecond.
+func (s *AwsGuardDutySource) getGranularity() time.Duration {
+	return time.Millisecond
+}
+
+// Identifier returns the unique identifier for this source type, used in the plugin system.
+func (s *AwsGuardDutySource) Identifier() string {
+	return AwsGuardDutySourceIdentifier
+}
+
+// Collect retrieves the findings updated in the collection time range.
+//
+// GuardDuty retains findings for 90 days, so the start of the time range is limited to this period.
+// The detectors of each region are listed, then the time range is split into windows, and for each window:
+//  1. The IDs of the findings updated in the window are listed for each detector.
+//  2. The findings are retrieved in batches of 50.
+//  3. The findings for all regions are sorted by their UpdatedAt time.
+//  4. Findings already collected are skipped based on the collection state.
+//  5. Each new finding is forwarded for processing.
+//
+// Each update of a finding is collected as a separate row, identified by the finding ID and UpdatedAt time.
+func (s *AwsGuardDutySource) Collect(ctx context.Context) error {
+	from := s.CollectionTimeRange.StartTime()
+	to := s.CollectionTimeRange.EndTime()
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if earliest := time.Now().Add(-findingRetentionPeriod); from.Before(earliest) {
+		slog.Info("Limiting collection to the 90 day GuardDuty finding retention period", "from", from, "earliest", earliest)
+		from = earliest
+	}
+
+	s.detectorIds = make(map[string][]string)
+	for _, region := range s.collector.Regions {
+		ids, err := s.listDetectors(ctx, region)
+		if err != nil {
+			return fmt.Errorf("failed to list detectors in region %s: %w", region, err)
+		}
+		if len(ids) == 0 {
+			slog.Info("No GuardDuty detectors found", "region", region)
+		}
+		s.detectorIds[region] = ids
+	}
+
+	if err := s.collector.Collect(ctx, s.CollectionState, from, to, findingsWindow, s.getRegionFindings, s.processFinding); err != nil {
+		return fmt.Errorf("failed to get findings, %w", err)
+	}
+
+	return s.collector.Error("GuardDuty")
+}
+
+// processFinding forwards a finding for processing
+func (s *AwsGuardDutySource) processFinding(ctx context.Context, f windowed_collection.Item[types.Finding]) error {
+	region := f.Region
+	row := &rowTypes.RowData{
+		Data: f.Value,
+		SourceEnrichment: &schema.SourceEnrichment{
+			CommonFields: schema.CommonFields{
+				TpSourceType:     AwsGuardDutySourceIdentifier,
+				TpSourceLocation: &region,
+			},
+		},
+	}
+
+	return s.OnRow(ctx, row)
+}
+
+// listDetectors returns the IDs of the GuardDuty detectors in a region
+func (s *AwsGuardDutySource) listDetectors(ctx context.Context, region string) ([]string, error) {
+	var detectorIds []string
+
+	paginator := guardduty.NewListDetectorsPaginator(s.collector.Clients[region], &guardduty.ListDetectorsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		detectorIds = append(detectorIds, output.DetectorIds...)
+	}
+
+	return detectorIds, nil
+}
+
+// getRegionFindings retrieves all findings for the detectors of a region updated in the given time range.
+func (s *AwsGuardDutySource) getRegionFindings(ctx context.Context, region string, from, to time.Time) ([]windowed_collection.Item[types.Finding], error) {
+	var findings []windowed_collection.Item[types.Finding]
+
+	for _, detectorId := range s.detectorIds[region] {
+		detectorFindings, err := s.getFindings(ctx, region, detectorId, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("detector %s: %w", detectorId, err)
+		}
+		findings = append(findings, detectorFindings...)
+	}
+
+	return findings, nil
+}
+
+// getFindings retrieves all findings for a detector updated in the given time range.
+// The IDs of each page of findings listed by ListFindings are retrieved with a single GetFindings call.
+// A finding may be updated again after it is listed, so findings updated after the end time may be returned,
+// and are skipped by the collector.
+func (s *AwsGuardDutySource) getFindings(ctx context.Context, region, detectorId string, from, to time.Time) ([]windowed_collection.Item[types.Finding], error) {
+	var findings []windowed_collection.Item[types.Finding]
+
+	client := s.collector.Clients[region]
+	sortCriteria := &types.SortCriteria{
+		AttributeName: aws.String("updatedAt"),
+		OrderBy:       types.OrderByAsc,
+	}
+
+	input := &guardduty.ListFindingsInput{
+		DetectorId: &detectorId,
+		FindingCriteria: &types.FindingCriteria{
+			Criterion: map[string]types.Condition{
+				"updatedAt": {
+					GreaterThanOrEqual: aws.Int64(from.UnixMilli()),
+					LessThan:           aws.Int64(to.UnixMilli()),
+				},
+			},
+		},
+		MaxResults:   aws.Int32(maxFindingIds),
+		SortCriteria: sortCriteria,
+	}
+
+	paginator := guardduty.NewListFindingsPaginator(client, input)
+	for paginator.HasMorePages() {
+		listOutput, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list findings, %w", err)
+		}
+		if len(listOutput.FindingIds) == 0 {
+			continue
+		}
+
+		getOutput, err := client.GetFindings(ctx, &guardduty.GetFindingsInput{
+			DetectorId:   &detectorId,
+			FindingIds:   listOutput.FindingIds,
+			SortCriteria: sortCriteria,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get findings, %w", err)
+		}
+
+		for _, finding := range getOutput.Findings {
+			if finding.Id == nil || finding.UpdatedAt == nil {
+				s.collector.Errors = append(s.collector.Errors, fmt.Errorf("skipping finding with no ID or UpdatedAt for detector %s in region %s", detectorId, region))
+				continue
+			}
+			updatedAt, err := time.Parse(time.RFC3339, *finding.UpdatedAt)
+			if err != nil {
+				s.collector.Errors = append(s.collector.Errors, fmt.Errorf("skipping finding %s with invalid UpdatedAt: %w", *finding.Id, err))
+				continue
+			}
+			findings = append(findings, windowed_collection.Item[types.Finding]{
+				Region: region,
+				// a finding is collected each time it is updated
+				Id:    fmt.Sprintf("%s/%s", *finding.Id, *finding.UpdatedAt),
+				Time:  updatedAt,
+				Value: finding,
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// initClients initializes an AWS GuardDuty client for each configured region.
+// If no regions are configured, the region of the connection is used.
+func (s *AwsGuardDutySource) initClients(ctx context.Context) error {
+	newClient := func(cfg aws.Config) *guardduty.Client {
+		return guardduty.NewFromConfig(cfg)
+	}
+	return s.collector.Init(ctx, s.Connection, s.Config.Regions, newClient)
+}
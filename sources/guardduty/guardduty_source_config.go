@@ -0,0 +1,25 @@
+package guardduty
+
+import "fmt"
+
+// AwsGuardDutySourceConfig defines the configuration parameters for collecting findings from the GuardDuty API.
+type AwsGuardDutySourceConfig struct {
+	// Regions are the AWS regions to collect findings from.
+	// If not specified, the region of the connection is used.
+	Regions []string `hcl:"regions,optional"`
+}
+
+// Validate checks if the configuration is valid.
+func (c *AwsGuardDutySourceConfig) Validate() error {
+	for _, region := range c.Regions {
+		if region == "" {
+			return fmt.Errorf("regions cannot contain an empty region")
+		}
+	}
+	return nil
+}
+
+// Identifier returns the unique identifier for this source type.
+func (c *AwsGuardDutySourceConfig) Identifier() string {
+	return AwsGuardDutySourceIdentifier
+}
@@ -0,0 +1,101 @@
+package guardduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/guardduty"
+)
+
+// findingsHTTPClient lists two pages of findings for each detector, recording the finding IDs of each GetFindings request
+type findingsHTTPClient struct {
+	updatedAt   string
+	getRequests []string
+}
+
+func (c *findingsHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	var request struct {
+		NextToken  string   `json:"nextToken"`
+		FindingIds []string `json:"findingIds"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+
+	var res map[string]any
+	detectorId := strings.Split(req.URL.Path, "/")[2]
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/findings") && request.NextToken == "":
+		res = map[string]any{"findingIds": []string{detectorId + "-1", detectorId + "-2"}, "nextToken": "page-2"}
+	case strings.HasSuffix(req.URL.Path, "/findings"):
+		res = map[string]any{"findingIds": []string{detectorId + "-3"}}
+	case strings.HasSuffix(req.URL.Path, "/findings/get"):
+		c.getRequests = append(c.getRequests, strings.Join(request.FindingIds, ","))
+		var findings []map[string]any
+		for _, id := range request.FindingIds {
+			findings = append(findings, map[string]any{"id": id, "updatedAt": c.updatedAt})
+		}
+		res = map[string]any{"findings": findings}
+	default:
+		return nil, fmt.Errorf("unexpected request %s", req.URL.Path)
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func TestGetRegionFindings(t *testing.T) {
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(findingsWindow)
+
+	httpClient := &findingsHTTPClient{updatedAt: "2025-04-01T10:20:30Z"}
+
+	s := &AwsGuardDutySource{}
+	s.collector.Clients = map[string]*guardduty.Client{
+		"us-east-1": guardduty.New(guardduty.Options{
+			Region:      "us-east-1",
+			Credentials: aws.AnonymousCredentials{},
+			HTTPClient:  httpClient,
+		}),
+	}
+	s.detectorIds = map[string][]string{"us-east-1": {"d1", "d2"}}
+
+	findings, err := s.getRegionFindings(context.Background(), "us-east-1", from, to)
+	if err != nil {
+		t.Fatalf("getRegionFindings() error = %v", err)
+	}
+
+	// each page of listed findings is retrieved with a single GetFindings call, for each detector
+	wantRequests := []string{"d1-1,d1-2", "d1-3", "d2-1,d2-2", "d2-3"}
+	if !reflect.DeepEqual(httpClient.getRequests, wantRequests) {
+		t.Errorf("GetFindings requests = %v, want %v", httpClient.getRequests, wantRequests)
+	}
+
+	var ids []string
+	for _, f := range findings {
+		ids = append(ids, f.Id)
+	}
+	wantIds := []string{
+		"d1-1/2025-04-01T10:20:30Z", "d1-2/2025-04-01T10:20:30Z", "d1-3/2025-04-01T10:20:30Z",
+		"d2-1/2025-04-01T10:20:30Z", "d2-2/2025-04-01T10:20:30Z", "d2-3/2025-04-01T10:20:30Z",
+	}
+	if !reflect.DeepEqual(ids, wantIds) {
+		t.Errorf("findings = %v, want %v", ids, wantIds)
+	}
+}
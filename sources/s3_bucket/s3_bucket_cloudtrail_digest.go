@@ -0,0 +1,246 @@
+package s3_bucket
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+// The source enrichment metadata keys used to pass the results of CloudTrail digest verification to the
+// aws_cloudtrail_digest table. The verified keys are "true" or "false", and are only set if the verification
+// was attempted. The errors are a JSON encoded array of strings.
+const (
+	MetadataDigestSignatureVerified = "digest_signature_verified"
+	MetadataDigestSignatureErrors   = "digest_signature_errors"
+	MetadataDigestLogFilesVerified  = "digest_log_files_verified"
+	MetadataDigestLogFileErrors     = "digest_log_file_errors"
+)
+
+const (
+	cloudTrailDigestSignatureAlgorithm = "SHA256withRSA"
+	cloudTrailDigestHashAlgorithm      = "SHA-256"
+)
+
+// cloudTrailDigest contains the fields of a CloudTrail digest file required to verify it
+type cloudTrailDigest struct {
+	DigestEndTime               string `json:"digestEndTime"`
+	DigestPublicKeyFingerprint  string `json:"digestPublicKeyFingerprint"`
+	DigestS3Bucket              string `json:"digestS3Bucket"`
+	DigestS3Object              string `json:"digestS3Object"`
+	DigestSignatureAlgorithm    string `json:"digestSignatureAlgorithm"`
+	PreviousDigestHashAlgorithm string `json:"previousDigestHashAlgorithm"`
+	PreviousDigestHashValue     string `json:"previousDigestHashValue"`
+	PreviousDigestS3Bucket      string `json:"previousDigestS3Bucket"`
+	PreviousDigestS3Object      string `json:"previousDigestS3Object"`
+	PreviousDigestSignature     string `json:"previousDigestSignature"`
+	LogFiles                    []struct {
+		HashAlgorithm string `json:"hashAlgorithm"`
+		HashValue     string `json:"hashValue"`
+		S3Bucket      string `json:"s3Bucket"`
+		S3Object      string `json:"s3Object"`
+	} `json:"logFiles"`
+}
+
+// isCloudTrailDigestKey returns whether the object key is that of a CloudTrail digest file
+func isCloudTrailDigestKey(key string) bool {
+	return strings.Contains(key, "/CloudTrail-Digest/") && strings.HasSuffix(key, ".json.gz")
+}
+
+// verifyCloudTrailDigest verifies the downloaded CloudTrail digest file, and adds the results to the source
+// enrichment metadata of the artifact. The signature is verified if public keys are configured, and the log files
+// and previous digest file referenced by the digest are verified if verify_cloudtrail_digest_log_files is set.
+// Verification failures are recorded in the metadata rather than failing the artifact, so that they can be reported.
+func (s *AwsS3BucketSource) verifyCloudTrailDigest(ctx context.Context, info *types.ArtifactInfo, localFilePath string, objectMetadata map[string]string) error {
+	compressed, err := os.ReadFile(localFilePath)
+	if err != nil {
+		return fmt.Errorf("%s: failed to read CloudTrail digest file: %w", info.Name, err)
+	}
+	content, err := gunzip(compressed)
+	if err != nil {
+		return fmt.Errorf("%s: failed to decompress CloudTrail digest file: %w", info.Name, err)
+	}
+	var digest cloudTrailDigest
+	if err := json.Unmarshal(content, &digest); err != nil {
+		return fmt.Errorf("%s: failed to decode CloudTrail digest file: %w", info.Name, err)
+	}
+
+	metadata := make(map[string]string)
+
+	if len(s.Config.CloudTrailDigestPublicKeys) > 0 {
+		var signatureErrors []string
+		if err := verifyCloudTrailDigestSignature(&digest, content, objectMetadata, s.Config.CloudTrailDigestPublicKeys); err != nil {
+			slog.Warn("CloudTrail digest signature verification failed", "bucket", s.Config.Bucket, "key", info.Name, "error", err)
+			signatureErrors = append(signatureErrors, err.Error())
+		}
+		addVerificationMetadata(metadata, MetadataDigestSignatureVerified, MetadataDigestSignatureErrors, signatureErrors)
+	}
+
+	if typehelpers.BoolValue(s.Config.VerifyCloudTrailDigestLogFiles) {
+		var logFileErrors []string
+		for _, logFile := range digest.LogFiles {
+			if err := s.verifyObjectHash(ctx, logFile.S3Bucket, logFile.S3Object, logFile.HashAlgorithm, logFile.HashValue); err != nil {
+				logFileErrors = append(logFileErrors, err.Error())
+			}
+		}
+		// the previous digest is not set for the first digest file of a trail
+		if digest.PreviousDigestS3Object != "" {
+			if err := s.verifyObjectHash(ctx, digest.PreviousDigestS3Bucket, digest.PreviousDigestS3Object, digest.PreviousDigestHashAlgorithm, digest.PreviousDigestHashValue); err != nil {
+				logFileErrors = append(logFileErrors, fmt.Sprintf("previous digest: %s", err.Error()))
+			}
+		}
+		if len(logFileErrors) > 0 {
+			slog.Warn("CloudTrail digest log file verification failed", "bucket", s.Config.Bucket, "key", info.Name, "errors", len(logFileErrors))
+		}
+		addVerificationMetadata(metadata, MetadataDigestLogFilesVerified, MetadataDigestLogFileErrors, logFileErrors)
+	}
+
+	// add the results to the source enrichment, without modifying the metadata shared with other artifacts
+	enrichment := &schema.SourceEnrichment{}
+	if info.SourceEnrichment != nil {
+		*enrichment = *info.SourceEnrichment
+	}
+	merged := make(map[string]string, len(enrichment.Metadata)+len(metadata))
+	maps.Copy(merged, enrichment.Metadata)
+	maps.Copy(merged, metadata)
+	enrichment.Metadata = merged
+	info.SourceEnrichment = enrichment
+
+	return nil
+}
+
+func addVerificationMetadata(metadata map[string]string, verifiedKey, errorsKey string, verificationErrors []string) {
+	metadata[verifiedKey] = strconv.FormatBool(len(verificationErrors) == 0)
+	if len(verificationErrors) > 0 {
+		errorsJSON, _ := json.Marshal(verificationErrors)
+		metadata[errorsKey] = string(errorsJSON)
+	}
+}
+
+// verifyCloudTrailDigestSignature verifies the signature of a digest file, which is held in the metadata of the
+// digest object, using the public key with the fingerprint recorded in the digest.
+// See https://docs.aws.amazon.com/awscloudtrail/latest/userguide/cloudtrail-log-file-custom-validation.html
+func verifyCloudTrailDigestSignature(digest *cloudTrailDigest, content []byte, objectMetadata map[string]string, publicKeys map[string]string) error {
+	signatureHex := objectMetadata["signature"]
+	if signatureHex == "" {
+		return errors.New("the digest object has no signature metadata")
+	}
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if algorithm := objectMetadata["signature-algorithm"]; algorithm != "" && algorithm != cloudTrailDigestSignatureAlgorithm {
+		return fmt.Errorf("unsupported signature algorithm %s", algorithm)
+	}
+
+	encodedKey, ok := publicKeys[digest.DigestPublicKeyFingerprint]
+	if !ok {
+		return fmt.Errorf("no public key configured with fingerprint %s", digest.DigestPublicKeyFingerprint)
+	}
+	publicKey, err := parseCloudTrailPublicKey(encodedKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key with fingerprint %s: %w", digest.DigestPublicKeyFingerprint, err)
+	}
+
+	// the first digest of a trail has no previous signature, which is signed as "null"
+	previousSignature := digest.PreviousDigestSignature
+	if previousSignature == "" {
+		previousSignature = "null"
+	}
+	contentHash := sha256.Sum256(content)
+	dataToSign := strings.Join([]string{
+		digest.DigestEndTime,
+		digest.DigestS3Bucket + "/" + digest.DigestS3Object,
+		hex.EncodeToString(contentHash[:]),
+		previousSignature,
+	}, "\n")
+
+	hashed := sha256.Sum256([]byte(dataToSign))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signature); err != nil {
+		return errors.New("the signature does not match the digest file")
+	}
+	return nil
+}
+
+// parseCloudTrailPublicKey parses a base64 encoded CloudTrail public key, as returned by the ListPublicKeys API
+// (a DER encoded PKCS#1 RSA public key)
+func parseCloudTrailPublicKey(encoded string) (*rsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA public key, got %T", key)
+	}
+	return rsaKey, nil
+}
+
+// verifyObjectHash verifies the SHA-256 hash of the uncompressed content of a gzipped object referenced by a digest
+func (s *AwsS3BucketSource) verifyObjectHash(ctx context.Context, bucket, key, algorithm, expected string) error {
+	location := fmt.Sprintf("s3://%s/%s", bucket, key)
+	if algorithm != cloudTrailDigestHashAlgorithm {
+		return fmt.Errorf("%s: unsupported hash algorithm %s", location, algorithm)
+	}
+
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &key})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return fmt.Errorf("%s: the file has been deleted", location)
+		}
+		return fmt.Errorf("%s: failed to get the file: %w", location, err)
+	}
+	defer output.Body.Close()
+
+	reader, err := gzip.NewReader(output.Body)
+	if err != nil {
+		return fmt.Errorf("%s: failed to decompress the file: %w", location, err)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return fmt.Errorf("%s: failed to read the file: %w", location, err)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return fmt.Errorf("%s: the file has been modified (expected hash %s, got %s)", location, expected, actual)
+	}
+	return nil
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,92 @@
+package s3_bucket
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestVerifyCloudTrailDigestSignature(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeys := map[string]string{
+		"fingerprint": base64.StdEncoding.EncodeToString(x509.MarshalPKCS1PublicKey(&privateKey.PublicKey)),
+	}
+
+	digest := &cloudTrailDigest{
+		DigestEndTime:              "2015-08-17T15:01:31Z",
+		DigestPublicKeyFingerprint: "fingerprint",
+		DigestS3Bucket:             "my-bucket",
+		DigestS3Object:             "AWSLogs/111122223333/CloudTrail-Digest/us-east-1/2015/08/17/digest.json.gz",
+	}
+	content, _ := json.Marshal(digest)
+
+	// sign the digest as CloudTrail does, with no previous digest signature
+	contentHash := sha256.Sum256(content)
+	dataToSign := strings.Join([]string{digest.DigestEndTime, digest.DigestS3Bucket + "/" + digest.DigestS3Object, hex.EncodeToString(contentHash[:]), "null"}, "\n")
+	hashed := sha256.Sum256([]byte(dataToSign))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	validMetadata := map[string]string{"signature": hex.EncodeToString(signature), "signature-algorithm": cloudTrailDigestSignatureAlgorithm}
+
+	tests := []struct {
+		name     string
+		content  []byte
+		metadata map[string]string
+		keys     map[string]string
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			content:  content,
+			metadata: validMetadata,
+			keys:     publicKeys,
+		},
+		{
+			name:     "modified content",
+			content:  append(content, ' '),
+			metadata: validMetadata,
+			keys:     publicKeys,
+			wantErr:  "does not match",
+		},
+		{
+			name:     "no signature",
+			content:  content,
+			metadata: map[string]string{},
+			keys:     publicKeys,
+			wantErr:  "no signature",
+		},
+		{
+			name:     "unknown fingerprint",
+			content:  content,
+			metadata: validMetadata,
+			keys:     map[string]string{"other": publicKeys["fingerprint"]},
+			wantErr:  "no public key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyCloudTrailDigestSignature(digest, tt.content, tt.metadata, tt.keys)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("verifyCloudTrailDigestSignature() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("verifyCloudTrailDigestSignature() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
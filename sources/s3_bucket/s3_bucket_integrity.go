@@ -0,0 +1,115 @@
+package s3_bucket
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go/middleware"
+
+	typehelpers "github.com/turbot/go-kit/types"
+)
+
+const (
+	ChecksumAlgorithmSHA256 = "SHA256"
+	ChecksumAlgorithmCRC32C = "CRC32C"
+	ChecksumAlgorithmCRC32  = "CRC32"
+	ChecksumAlgorithmMD5    = "MD5"
+
+	// validateOutputChecksumMiddlewareID is the ID of the SDK middleware which validates response checksums
+	validateOutputChecksumMiddlewareID = "AWSChecksum:ValidateOutputPayloadChecksum"
+)
+
+// ObjectIntegrityError is returned by DownloadArtifact when checksum verification is enabled and the
+// downloaded content of an object does not match the checksum S3 holds for it
+type ObjectIntegrityError struct {
+	Bucket    string
+	Key       string
+	VersionId *string
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+func (e *ObjectIntegrityError) Error() string {
+	name := e.Key
+	if e.VersionId != nil {
+		name = versionedArtifactName(e.Key, *e.VersionId)
+	}
+	return fmt.Sprintf("%s: integrity verification failed for object in %s, %s checksum mismatch (expected %s, got %s)", name, e.Bucket, e.Algorithm, e.Expected, e.Actual)
+}
+
+// objectVerifier computes a checksum of object content as it is downloaded and compares it with the checksum
+// returned by S3
+type objectVerifier struct {
+	algorithm string
+	expected  string
+	hash      hash.Hash
+	encode    func([]byte) string
+}
+
+// newObjectVerifier returns a verifier for the strongest checksum available in the GetObject response,
+// in order of preference SHA256, CRC32C, CRC32 and finally the ETag (MD5).
+// If the response contains no checksum that can be verified against the whole object, nil is returned.
+func newObjectVerifier(output *s3.GetObjectOutput) *objectVerifier {
+	// composite checksums (of the form <checksum>-<part count>) are a checksum of the part checksums of a
+	// multipart upload, so cannot be verified against the object content
+	if checksum := fullObjectChecksum(output.ChecksumSHA256); checksum != "" {
+		return &objectVerifier{algorithm: ChecksumAlgorithmSHA256, expected: checksum, hash: sha256.New(), encode: base64.StdEncoding.EncodeToString}
+	}
+	if checksum := fullObjectChecksum(output.ChecksumCRC32C); checksum != "" {
+		return &objectVerifier{algorithm: ChecksumAlgorithmCRC32C, expected: checksum, hash: crc32.New(crc32.MakeTable(crc32.Castagnoli)), encode: base64.StdEncoding.EncodeToString}
+	}
+	if checksum := fullObjectChecksum(output.ChecksumCRC32); checksum != "" {
+		return &objectVerifier{algorithm: ChecksumAlgorithmCRC32, expected: checksum, hash: crc32.NewIEEE(), encode: base64.StdEncoding.EncodeToString}
+	}
+
+	// fall back to the ETag - this is only the MD5 of the content for objects which were not uploaded
+	// using multipart upload and are not encrypted with SSE-KMS or SSE-C
+	etag := strings.Trim(typehelpers.SafeString(output.ETag), `"`)
+	if etag == "" || strings.Contains(etag, "-") || output.SSECustomerAlgorithm != nil {
+		return nil
+	}
+	switch output.ServerSideEncryption {
+	case s3types.ServerSideEncryptionAwsKms, s3types.ServerSideEncryptionAwsKmsDsse:
+		return nil
+	}
+	return &objectVerifier{algorithm: ChecksumAlgorithmMD5, expected: strings.ToLower(etag), hash: md5.New(), encode: hex.EncodeToString}
+}
+
+// Write adds object content to the checksum
+func (v *objectVerifier) Write(p []byte) (int, error) {
+	return v.hash.Write(p)
+}
+
+// Verify compares the checksum of the content written with the expected checksum,
+// returning the computed checksum and whether it matches
+func (v *objectVerifier) Verify() (string, bool) {
+	actual := v.encode(v.hash.Sum(nil))
+	return actual, actual == v.expected
+}
+
+// fullObjectChecksum returns the checksum if it is a checksum of the full object, or empty string otherwise
+func fullObjectChecksum(checksum *string) string {
+	c := typehelpers.SafeString(checksum)
+	if strings.Contains(c, "-") {
+		return ""
+	}
+	return c
+}
+
+// removeOutputChecksumValidation removes the SDK response checksum validation from the GetObject middleware stack.
+// When verifying integrity we compute the checksum ourselves, so that a mismatch can be reported
+// as an [ObjectIntegrityError] rather than as a generic read error.
+func removeOutputChecksumValidation(stack *middleware.Stack) error {
+	// the middleware is only added when checksum mode is enabled - ignore the error if it is not present
+	_, _ = stack.Deserialize.Remove(validateOutputChecksumMiddlewareID)
+	return nil
+}
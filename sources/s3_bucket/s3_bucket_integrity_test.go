@@ -0,0 +1,89 @@
+package s3_bucket
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func TestObjectVerifier(t *testing.T) {
+	content := []byte("hello world")
+
+	tests := []struct {
+		name          string
+		output        *s3.GetObjectOutput
+		wantAlgorithm string
+		wantMatch     bool
+	}{
+		{
+			name: "sha256 preferred",
+			output: &s3.GetObjectOutput{
+				ChecksumSHA256: aws.String("uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek="),
+				ChecksumCRC32:  aws.String("DUoRhQ=="),
+			},
+			wantAlgorithm: ChecksumAlgorithmSHA256,
+			wantMatch:     true,
+		},
+		{
+			name:          "crc32c",
+			output:        &s3.GetObjectOutput{ChecksumCRC32C: aws.String("yZRlqg==")},
+			wantAlgorithm: ChecksumAlgorithmCRC32C,
+			wantMatch:     true,
+		},
+		{
+			name:          "crc32",
+			output:        &s3.GetObjectOutput{ChecksumCRC32: aws.String("DUoRhQ==")},
+			wantAlgorithm: ChecksumAlgorithmCRC32,
+			wantMatch:     true,
+		},
+		{
+			name:          "crc32 mismatch",
+			output:        &s3.GetObjectOutput{ChecksumCRC32: aws.String("AAAAAA==")},
+			wantAlgorithm: ChecksumAlgorithmCRC32,
+			wantMatch:     false,
+		},
+		{
+			name: "composite checksum falls back to etag",
+			output: &s3.GetObjectOutput{
+				ChecksumCRC32: aws.String("DUoRhQ==-2"),
+				ETag:          aws.String(`"5eb63bbbe01eeed093cb22bb8f5acdc3"`),
+			},
+			wantAlgorithm: ChecksumAlgorithmMD5,
+			wantMatch:     true,
+		},
+		{
+			name:   "multipart etag",
+			output: &s3.GetObjectOutput{ETag: aws.String(`"9b2cf535f27731c974343645a3985328-2"`)},
+		},
+		{
+			name: "kms encrypted etag",
+			output: &s3.GetObjectOutput{
+				ETag:                 aws.String(`"5eb63bbbe01eeed093cb22bb8f5acdc3"`),
+				ServerSideEncryption: s3types.ServerSideEncryptionAwsKms,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newObjectVerifier(tt.output)
+			if tt.wantAlgorithm == "" {
+				if v != nil {
+					t.Fatalf("newObjectVerifier() = %s verifier, want nil", v.algorithm)
+				}
+				return
+			}
+			if v == nil {
+				t.Fatalf("newObjectVerifier() = nil, want %s verifier", tt.wantAlgorithm)
+			}
+			if v.algorithm != tt.wantAlgorithm {
+				t.Errorf("algorithm = %s, want %s", v.algorithm, tt.wantAlgorithm)
+			}
+			_, _ = v.Write(content)
+			if actual, ok := v.Verify(); ok != tt.wantMatch {
+				t.Errorf("Verify() = %s, %v, want match %v", actual, ok, tt.wantMatch)
+			}
+		})
+	}
+}
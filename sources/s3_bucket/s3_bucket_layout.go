@@ -0,0 +1,44 @@
+package s3_bucket
+
+import (
+	"github.com/elastic/go-grok"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/pipe-fittings/v2/filter"
+)
+
+// getKeyMetadata applies each layout in turn to the given object key and returns the index of the first layout
+// which matches, along with the metadata captured from the key. If no layout matches, the index is -1.
+//
+// This mirrors the matching performed for files by ArtifactSourceImpl.WalkNode, for use where the source
+// builds the artifact info itself or needs to know which of the layouts matched.
+func getKeyMetadata(g *grok.Grok, key string, layouts []string) (int, map[string]string, error) {
+	for i, layout := range layouts {
+		if err := g.Compile(layout, true); err != nil {
+			return -1, nil, err
+		}
+		if !g.MatchString(key) {
+			continue
+		}
+		metadata, err := g.Parse([]byte(key))
+		if err != nil {
+			return -1, nil, err
+		}
+		return i, helpers.ByteMapToStringMap(metadata), nil
+	}
+
+	return -1, nil, nil
+}
+
+// metadataSatisfiesFilters returns whether the metadata captured from an object key satisfies the partition filters.
+//
+// This mirrors the filtering performed for files by ArtifactSourceImpl.WalkNode, for use where the source
+// builds the artifact info itself.
+func metadataSatisfiesFilters(metadata map[string]string, filterMap map[string]*filter.SqlFilter) bool {
+	for key, value := range metadata {
+		// each filter is for a single key
+		if f, ok := filterMap[key]; ok && !f.Satisfied(map[string]string{key: value}) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package s3_bucket
+
+import (
+	"testing"
+
+	"github.com/elastic/go-grok"
+	"github.com/turbot/tailpipe-plugin-sdk/helpers"
+)
+
+func TestGetKeyMetadataWithFilters(t *testing.T) {
+	layouts := []string{"AWSLogs/%{NUMBER:account_id}/CloudTrail/%{DATA:region}/%{DATA}.json.gz"}
+	filterMap, err := helpers.BuildFilterMap([]string{"account_id = '123456789012'"})
+	if err != nil {
+		t.Fatalf("failed to build filter map: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		key           string
+		wantMatch     bool
+		wantSatisfied bool
+	}{
+		{
+			name:          "matches layout and filter",
+			key:           "AWSLogs/123456789012/CloudTrail/us-east-1/file.json.gz",
+			wantMatch:     true,
+			wantSatisfied: true,
+		},
+		{
+			name:          "matches layout but not filter",
+			key:           "AWSLogs/210987654321/CloudTrail/us-east-1/file.json.gz",
+			wantMatch:     true,
+			wantSatisfied: false,
+		},
+		{
+			name:      "does not match layout",
+			key:       "AWSLogs/123456789012/Config/us-east-1/file.json.gz",
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layoutIdx, metadata, err := getKeyMetadata(grok.New(), tt.key, layouts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (layoutIdx != -1) != tt.wantMatch {
+				t.Fatalf("getKeyMetadata() layout index = %d, want match %v", layoutIdx, tt.wantMatch)
+			}
+			if !tt.wantMatch {
+				return
+			}
+			if got := metadataSatisfiesFilters(metadata, filterMap); got != tt.wantSatisfied {
+				t.Errorf("metadataSatisfiesFilters() = %v, want %v", got, tt.wantSatisfied)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package s3_bucket
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/elastic/go-grok"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/pipe-fittings/v2/filter"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/context_values"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+// versionIdSeparator separates the object key from the version ID in the name of a versioned artifact.
+// This mirrors the query string S3 uses to address a specific version of an object,
+// e.g. AWSLogs/123456789012/CloudTrail/us-east-1/2025/01/01/file.json.gz?versionId=3HL4kqtJlcpXroDTDmJ
+const versionIdSeparator = "?versionId="
+
+// versionedArtifactName returns the artifact name for a specific version of an object
+// - this is used as the artifact identifier in the collection state and as the tp_source_location
+func versionedArtifactName(key, versionId string) string {
+	return key + versionIdSeparator + versionId
+}
+
+// splitVersionedArtifactName splits an artifact name into the object key and version ID
+// if the name does not contain a version ID, the version ID returned is nil
+func splitVersionedArtifactName(name string) (string, *string) {
+	idx := strings.LastIndex(name, versionIdSeparator)
+	if idx == -1 {
+		return name, nil
+	}
+	versionId := name[idx+len(versionIdSeparator):]
+	return name[:idx], &versionId
+}
+
+// walkS3Versions walks the bucket using ListObjectVersions, discovering either the current version or every version
+// of each object (depending on the object_versions config). Delete markers are never collected.
+// In preview mode, the versions are recorded in the preview report rather than discovered.
+func (s *AwsS3BucketSource) walkS3Versions(ctx context.Context, bucket string, prefix string, layouts []string, filterMap map[string]*filter.SqlFilter, g *grok.Grok) error {
+	executionId, err := context_values.ExecutionIdFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	allVersions := typehelpers.SafeString(s.Config.ObjectVersions) == ObjectVersionsAll
+
+	paginator := s3.NewListObjectVersionsPaginator(s.client, &s3.ListObjectVersionsInput{
+		Bucket:    aws.String(bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			// fatal error - log and return
+			slog.Error("error getting next page of object versions", "bucket", bucket, "prefix", prefix, "error", err)
+			return fmt.Errorf("error getting next page of object versions, %w", err)
+		}
+
+		// Directories
+		s.walkCommonPrefixes(ctx, bucket, page.CommonPrefixes, layouts, filterMap, g, s.walkS3Versions)
+
+		// Delete markers - these have no content so are never collected
+		for _, marker := range page.DeleteMarkers {
+			slog.Debug("skipping delete marker", "key", typehelpers.SafeString(marker.Key), "version_id", typehelpers.SafeString(marker.VersionId))
+		}
+
+		// Object versions
+		for _, version := range page.Versions {
+			objKey := typehelpers.SafeString(version.Key)
+			if objKey == "" {
+				slog.Debug("skipping empty object key")
+				continue
+			}
+			// unless we are collecting all versions, only collect the current version
+			// (if the current version of a key is a delete marker, none of its versions will be latest)
+			if !allVersions && !typehelpers.BoolValue(version.IsLatest) {
+				continue
+			}
+
+			versionId := typehelpers.SafeString(version.VersionId)
+			// in preview mode, just record the version in the preview report
+			if s.preview != nil {
+				err = s.previewNode(objKey, versionedArtifactName(objKey, versionId), typehelpers.Int64Value(version.Size), layouts, filterMap, g)
+			} else {
+				err = s.walkVersionNode(ctx, objKey, versionId, layouts, filterMap, g)
+			}
+			if err != nil {
+				// non-fatal error - log and notify
+				slog.Error("error obtaining artifact info", "key", objKey, "version_id", versionId, "error", err)
+				s.NotifyError(ctx, executionId, fmt.Errorf("%s: failed to obtain artifact info", versionedArtifactName(objKey, versionId)))
+				err = nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkVersionNode is the equivalent of ArtifactSourceImpl.WalkNode for a single object version.
+// The key is matched against the layouts, but the artifact is named using both the key and the version ID,
+// so that each version is tracked separately in the collection state.
+func (s *AwsS3BucketSource) walkVersionNode(ctx context.Context, key string, versionId string, layouts []string, filterMap map[string]*filter.SqlFilter, g *grok.Grok) error {
+	layoutIdx, metadata, err := getKeyMetadata(g, key, layouts)
+	if err != nil {
+		return err
+	}
+	// if the key does not satisfy the layout or the partition filters, skip it
+	if layoutIdx == -1 || !metadataSatisfiesFilters(metadata, filterMap) {
+		return nil
+	}
+
+	name := versionedArtifactName(key, versionId)
+
+	// populate the enrichment fields the source is aware of
+	metadata[constants.TpSourceLocation] = name
+	metadata[constants.TpSourceType] = s.Identifier()
+
+	// create an artifact info - this will parse the timestamp of the artifact from the source enrichment metadata
+	artifactInfo, err := types.NewArtifactInfo(name, schema.NewSourceEnrichment(metadata), s.CollectionState.GetGranularity())
+	if err != nil {
+		return err
+	}
+
+	// if the artifact has a timestamp, check the from and to time
+	if !s.inCollectionTimeRange(artifactInfo.Timestamp) {
+		return nil
+	}
+
+	// check with the collection state whether we have already collected this version
+	if !s.CollectionState.ShouldCollect(artifactInfo.Identifier(), artifactInfo.Timestamp) {
+		return nil
+	}
+
+	return s.OnArtifactDiscovered(ctx, artifactInfo)
+}
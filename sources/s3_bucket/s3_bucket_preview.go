@@ -0,0 +1,245 @@
+package s3_bucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/elastic/go-grok"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/pipe-fittings/v2/filter"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/context_values"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+const (
+	// previewMaxMatchedKeys is the maximum number of matched keys included in the preview report
+	previewMaxMatchedKeys = 10000
+	// previewMaxUnmatchedKeys is the maximum number of unmatched keys included in the preview report
+	previewMaxUnmatchedKeys = 100
+)
+
+// previewReport is the result of a preview of the bucket, describing the objects which would be collected
+type previewReport struct {
+	Bucket  string          `json:"bucket"`
+	Prefix  string          `json:"prefix,omitempty"`
+	From    *time.Time      `json:"from,omitempty"`
+	To      *time.Time      `json:"to,omitempty"`
+	Layouts []previewLayout `json:"layouts"`
+
+	// the objects which match a layout, satisfy the partition filters and are in the collection time range
+	MatchedCount          int                       `json:"matched_count"`
+	MatchedBytes          int64                     `json:"matched_bytes"`
+	MatchedKeys           []previewKey              `json:"matched_keys"`
+	MatchedKeysTruncated  bool                      `json:"matched_keys_truncated,omitempty"`
+	FieldCounts           map[string]map[string]int `json:"field_counts"`
+	FilteredCount         int                       `json:"filtered_count"`
+	OutsideTimeRangeCount int                       `json:"outside_time_range_count"`
+
+	// objects in the directories which were walked, but which do not match any layout
+	UnmatchedCount         int      `json:"unmatched_count"`
+	UnmatchedKeys          []string `json:"unmatched_keys"`
+	UnmatchedKeysTruncated bool     `json:"unmatched_keys_truncated,omitempty"`
+}
+
+// previewLayout is one of the layout variants expanded from the optional segments of the file layout
+type previewLayout struct {
+	Layout       string `json:"layout"`
+	MatchedCount int    `json:"matched_count"`
+}
+
+// previewKey is an object which would be collected
+type previewKey struct {
+	Key       string     `json:"key"`
+	Size      int64      `json:"size"`
+	Layout    int        `json:"layout"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+func newPreviewReport(bucket, prefix string, layouts []string, from, to time.Time) *previewReport {
+	r := &previewReport{
+		Bucket:        bucket,
+		Prefix:        prefix,
+		MatchedKeys:   []previewKey{},
+		FieldCounts:   make(map[string]map[string]int),
+		UnmatchedKeys: []string{},
+	}
+	if !from.IsZero() {
+		r.From = &from
+	}
+	if !to.IsZero() {
+		r.To = &to
+	}
+	for _, l := range layouts {
+		r.Layouts = append(r.Layouts, previewLayout{Layout: l})
+	}
+	return r
+}
+
+// onMatched records an object which would be collected
+func (r *previewReport) onMatched(key string, size int64, layoutIdx int, timestamp time.Time, metadata map[string]string) {
+	r.MatchedCount++
+	r.MatchedBytes += size
+	r.Layouts[layoutIdx].MatchedCount++
+
+	for field, value := range metadata {
+		if _, ok := r.FieldCounts[field]; !ok {
+			r.FieldCounts[field] = make(map[string]int)
+		}
+		r.FieldCounts[field][value]++
+	}
+
+	if len(r.MatchedKeys) < previewMaxMatchedKeys {
+		k := previewKey{Key: key, Size: size, Layout: layoutIdx}
+		if !timestamp.IsZero() {
+			k.Timestamp = &timestamp
+		}
+		r.MatchedKeys = append(r.MatchedKeys, k)
+	} else {
+		r.MatchedKeysTruncated = true
+	}
+}
+
+// onUnmatched records an object which does not match any layout
+func (r *previewReport) onUnmatched(key string) {
+	r.UnmatchedCount++
+	if len(r.UnmatchedKeys) < previewMaxUnmatchedKeys {
+		r.UnmatchedKeys = append(r.UnmatchedKeys, key)
+	} else {
+		r.UnmatchedKeysTruncated = true
+	}
+}
+
+// previewS3 performs discovery only, listing the bucket and applying the layouts, partition filters and time range,
+// and reports which objects would be collected - no artifacts are downloaded
+func (s *AwsS3BucketSource) previewS3(ctx context.Context, prefix string, layouts []string, filterMap map[string]*filter.SqlFilter, g *grok.Grok) error {
+	s.preview = newPreviewReport(s.Config.Bucket, prefix, layouts, s.CollectionTimeRange.LowerBoundary, s.CollectionTimeRange.UpperBoundary)
+
+	// if object versions are enabled, list object versions rather than objects
+	walkFunc := s.walkS3Preview
+	if s.Config.ObjectVersions != nil {
+		walkFunc = s.walkS3Versions
+	}
+
+	err := walkFunc(ctx, s.Config.Bucket, prefix, layouts, filterMap, g)
+	if err != nil {
+		return err
+	}
+
+	r := s.preview
+	for i, l := range r.Layouts {
+		slog.Info("preview layout", "bucket", r.Bucket, "index", i, "layout", l.Layout, "matched", l.MatchedCount)
+	}
+	slog.Info("preview complete", "bucket", r.Bucket, "prefix", r.Prefix, "matched", r.MatchedCount, "matched_bytes", r.MatchedBytes, "filtered", r.FilteredCount, "outside_time_range", r.OutsideTimeRangeCount, "unmatched", r.UnmatchedCount, "field_counts", r.FieldCounts)
+
+	if s.Config.PreviewReportPath != nil {
+		if err := writePreviewReport(*s.Config.PreviewReportPath, r); err != nil {
+			return fmt.Errorf("failed to write preview report, %w", err)
+		}
+		slog.Info("preview report written", "path", *s.Config.PreviewReportPath)
+	}
+	return nil
+}
+
+// walkS3Preview is the equivalent of walkS3 for preview mode, recording each object in the preview report
+// rather than discovering it
+func (s *AwsS3BucketSource) walkS3Preview(ctx context.Context, bucket string, prefix string, layouts []string, filterMap map[string]*filter.SqlFilter, g *grok.Grok) error {
+	executionId, err := context_values.ExecutionIdFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			// fatal error - log and return
+			slog.Error("error getting next page", "bucket", bucket, "prefix", prefix, "error", err)
+			return fmt.Errorf("error getting next page, %w", err)
+		}
+
+		// Directories
+		s.walkCommonPrefixes(ctx, bucket, page.CommonPrefixes, layouts, filterMap, g, s.walkS3Preview)
+
+		// Files
+		for _, obj := range page.Contents {
+			objKey := typehelpers.SafeString(obj.Key)
+			if objKey == "" {
+				continue
+			}
+			err = s.previewNode(objKey, objKey, typehelpers.Int64Value(obj.Size), layouts, filterMap, g)
+			if err != nil {
+				// non-fatal error - log and notify
+				slog.Error("error obtaining artifact info", "key", objKey, "error", err)
+				s.NotifyError(ctx, executionId, fmt.Errorf("%s: failed to obtain artifact info", objKey))
+			}
+		}
+	}
+
+	return nil
+}
+
+// previewNode matches a single object against the layouts, partition filters and time range, recording the result
+// in the preview report
+// the name is the artifact name which would be collected - for a specific object version this includes the version ID
+func (s *AwsS3BucketSource) previewNode(key string, name string, size int64, layouts []string, filterMap map[string]*filter.SqlFilter, g *grok.Grok) error {
+	layoutIdx, metadata, err := getKeyMetadata(g, key, layouts)
+	if err != nil {
+		return err
+	}
+	if layoutIdx == -1 {
+		s.preview.onUnmatched(name)
+		return nil
+	}
+	if !metadataSatisfiesFilters(metadata, filterMap) {
+		s.preview.FilteredCount++
+		return nil
+	}
+
+	// take a copy of the captured fields for the report, before adding the enrichment fields
+	fields := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		fields[k] = v
+	}
+
+	metadata[constants.TpSourceLocation] = name
+	metadata[constants.TpSourceType] = s.Identifier()
+
+	artifactInfo, err := types.NewArtifactInfo(name, schema.NewSourceEnrichment(metadata), s.CollectionState.GetGranularity())
+	if err != nil {
+		return err
+	}
+
+	if !s.inCollectionTimeRange(artifactInfo.Timestamp) {
+		s.preview.OutsideTimeRangeCount++
+		return nil
+	}
+
+	s.preview.onMatched(name, size, layoutIdx, artifactInfo.Timestamp, fields)
+	return nil
+}
+
+func writePreviewReport(reportPath string, r *previewReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(reportPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(reportPath, data, 0644)
+}
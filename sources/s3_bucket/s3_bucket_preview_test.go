@@ -0,0 +1,56 @@
+package s3_bucket
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-grok"
+
+	"github.com/turbot/tailpipe-plugin-sdk/collection_state"
+	"github.com/turbot/tailpipe-plugin-sdk/helpers"
+)
+
+func TestPreviewNodeWithFilters(t *testing.T) {
+	layouts := []string{"AWSLogs/%{NUMBER:account_id}/CloudTrail/%{DATA:region}/%{DATA}.json.gz"}
+	filterMap, err := helpers.BuildFilterMap([]string{"account_id = '123456789012'"})
+	if err != nil {
+		t.Fatalf("failed to build filter map: %v", err)
+	}
+
+	state, err := collection_state.NewSaveableCollectionState(collection_state.NewTimeRangeCollectionState(), filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("NewSaveableCollectionState() error = %v", err)
+	}
+	s := &AwsS3BucketSource{}
+	s.CollectionState = state
+	s.preview = newPreviewReport("bucket", "", layouts, time.Time{}, time.Time{})
+
+	keys := []string{
+		"AWSLogs/123456789012/CloudTrail/us-east-1/a.json.gz",
+		"AWSLogs/210987654321/CloudTrail/us-east-1/b.json.gz",
+		"AWSLogs/123456789012/Config/us-east-1/c.json.gz",
+	}
+	for _, key := range keys {
+		if err := s.previewNode(key, key, 10, layouts, filterMap, grok.New()); err != nil {
+			t.Fatalf("previewNode() error = %v", err)
+		}
+	}
+
+	r := s.preview
+	if r.MatchedCount != 1 || len(r.MatchedKeys) != 1 || r.MatchedKeys[0].Key != keys[0] {
+		t.Errorf("matched = %d %v, want %s", r.MatchedCount, r.MatchedKeys, keys[0])
+	}
+	if r.MatchedBytes != 10 {
+		t.Errorf("matched bytes = %d, want 10", r.MatchedBytes)
+	}
+	if got := r.FieldCounts["account_id"]; len(got) != 1 || got["123456789012"] != 1 {
+		t.Errorf("account_id field counts = %v, want only 123456789012", got)
+	}
+	if r.FilteredCount != 1 {
+		t.Errorf("filtered = %d, want 1", r.FilteredCount)
+	}
+	if r.UnmatchedCount != 1 {
+		t.Errorf("unmatched = %d, want 1", r.UnmatchedCount)
+	}
+}
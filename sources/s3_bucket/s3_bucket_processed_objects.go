@@ -0,0 +1,189 @@
+package s3_bucket
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"golang.org/x/sync/semaphore"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/tailpipe-plugin-sdk/events"
+	"github.com/turbot/tailpipe-plugin-sdk/grpc/proto"
+)
+
+// processedObjectActionMaxParallel is the maximum number of objects which are tagged or copied concurrently
+const processedObjectActionMaxParallel = 10
+
+// processedObjectObserver observes the events raised by the source and the collector, recording the artifacts
+// which have been extracted without error
+//
+// An artifact is only considered processed if:
+//   - an ArtifactExtracted event is raised for it - this is not raised if loading or extraction fails,
+//     or if the artifact contains no rows, so empty artifacts are left untouched
+//   - no row errors (mapping or enrichment) were recorded for it - these are not returned to the source,
+//     but are recorded by source location in the status of the collector
+type processedObjectObserver struct {
+	mut       sync.Mutex
+	extracted []string
+	// status is the status event of the collector - the collector raises the same status event throughout
+	// the collection, so this reflects all row errors recorded by the time the collection completes
+	status *events.Status
+}
+
+func (o *processedObjectObserver) Notify(_ context.Context, e events.Event) error {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	switch e := e.(type) {
+	case *events.ArtifactExtracted:
+		o.extracted = append(o.extracted, e.Info.Name)
+	case *events.Status:
+		o.status = e
+	}
+	return nil
+}
+
+// artifactNames returns the names of the artifacts which have been extracted without any row errors
+func (o *processedObjectObserver) artifactNames() []string {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	// row errors are keyed by the source location of the row, which is the artifact name
+	var rowErrors map[string]*proto.RowErrorsByOperation
+	if o.status != nil && o.status.RowErrors != nil {
+		rowErrors = o.status.RowErrors.ToProto().Errors
+	}
+
+	var names []string
+	for _, name := range o.extracted {
+		if _, hasErrors := rowErrors[name]; hasErrors {
+			slog.Warn("not applying post-collection actions to object with row errors", "key", name)
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// hasProcessedObjectActions returns whether any post-collection actions are configured
+func (c *AwsS3BucketSourceConfig) hasProcessedObjectActions() bool {
+	return c.ProcessedObjectTag != nil || c.ProcessedObjectCopyBucket != nil || c.ProcessedObjectCopyPrefix != nil
+}
+
+// applyProcessedObjectActions tags and/or copies each object which was collected without error
+// objects which failed to load, had row errors or contained no rows are left untouched
+// errors are logged rather than returned, as the rows for the objects have already been collected
+func (s *AwsS3BucketSource) applyProcessedObjectActions(ctx context.Context) {
+	names := s.processedObjects.artifactNames()
+	if len(names) == 0 {
+		return
+	}
+	slog.Info("applying post-collection actions to processed objects", "bucket", s.Config.Bucket, "count", len(names))
+
+	// use the same timestamp for all objects processed in this collection
+	collectedAt := time.Now().UTC().Format(time.RFC3339)
+
+	sem := semaphore.NewWeighted(processedObjectActionMaxParallel)
+	var wg sync.WaitGroup
+	var failedMut sync.Mutex
+	var failed int
+
+	for _, name := range names {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			slog.Error("error acquiring semaphore", "error", err)
+			break
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer func() {
+				sem.Release(1)
+				wg.Done()
+			}()
+			key, versionId := splitVersionedArtifactName(name)
+			if err := s.applyProcessedObjectAction(ctx, key, versionId, collectedAt); err != nil {
+				slog.Error("error applying post-collection action to object", "bucket", s.Config.Bucket, "key", name, "error", err)
+				failedMut.Lock()
+				failed++
+				failedMut.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		slog.Warn("post-collection actions failed for some objects", "bucket", s.Config.Bucket, "failed", failed, "total", len(names))
+	}
+}
+
+// applyProcessedObjectAction applies the configured actions to a single object
+func (s *AwsS3BucketSource) applyProcessedObjectAction(ctx context.Context, key string, versionId *string, collectedAt string) error {
+	if s.Config.ProcessedObjectTag != nil {
+		if err := s.tagObject(ctx, key, versionId, *s.Config.ProcessedObjectTag, collectedAt); err != nil {
+			return fmt.Errorf("failed to tag object, %w", err)
+		}
+	}
+	if s.Config.ProcessedObjectCopyBucket != nil || s.Config.ProcessedObjectCopyPrefix != nil {
+		if err := s.copyObject(ctx, key, versionId); err != nil {
+			return fmt.Errorf("failed to copy object, %w", err)
+		}
+	}
+	return nil
+}
+
+// tagObject adds the tag to the object, preserving any existing tags
+func (s *AwsS3BucketSource) tagObject(ctx context.Context, key string, versionId *string, tagKey, tagValue string) error {
+	existing, err := s.client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket:    &s.Config.Bucket,
+		Key:       &key,
+		VersionId: versionId,
+	})
+	if err != nil {
+		return err
+	}
+
+	tags := []s3types.Tag{{Key: aws.String(tagKey), Value: aws.String(tagValue)}}
+	for _, tag := range existing.TagSet {
+		// replace the value if the object has previously been collected
+		if typehelpers.SafeString(tag.Key) == tagKey {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+
+	_, err = s.client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+		Bucket:    &s.Config.Bucket,
+		Key:       &key,
+		VersionId: versionId,
+		Tagging:   &s3types.Tagging{TagSet: tags},
+	})
+	return err
+}
+
+// copyObject copies the object to the processed bucket and/or prefix, retaining the object key
+func (s *AwsS3BucketSource) copyObject(ctx context.Context, key string, versionId *string) error {
+	destBucket := s.Config.Bucket
+	if s.Config.ProcessedObjectCopyBucket != nil {
+		destBucket = *s.Config.ProcessedObjectCopyBucket
+	}
+	destKey := path.Join(typehelpers.SafeString(s.Config.ProcessedObjectCopyPrefix), key)
+
+	copySource := url.PathEscape(s.Config.Bucket) + "/" + url.PathEscape(key)
+	if versionId != nil {
+		copySource += versionIdSeparator + url.QueryEscape(*versionId)
+	}
+
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     &destBucket,
+		Key:        &destKey,
+		CopySource: &copySource,
+	})
+	return err
+}
@@ -0,0 +1,105 @@
+package s3_bucket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/events"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+// recordingHTTPClient records the paths of the requests made, failing every request
+type recordingHTTPClient struct {
+	mut   sync.Mutex
+	paths []string
+}
+
+func (c *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.paths = append(c.paths, req.URL.Path)
+	return nil, errors.New("request not allowed")
+}
+
+func TestApplyProcessedObjectActions(t *testing.T) {
+	tests := []struct {
+		name      string
+		extracted []string
+		rowErrors []string
+		wantPaths []string
+	}{
+		{
+			name:      "no row errors",
+			extracted: []string{"logs/a.json"},
+			wantPaths: []string{"/logs/a.json"},
+		},
+		{
+			name:      "failing row",
+			extracted: []string{"logs/a.json"},
+			rowErrors: []string{"logs/a.json"},
+		},
+		{
+			name:      "failing row in other artifact",
+			extracted: []string{"logs/a.json", "logs/b.json"},
+			rowErrors: []string{"logs/b.json"},
+			wantPaths: []string{"/logs/a.json"},
+		},
+		{
+			name: "empty artifact",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			observer := &processedObjectObserver{}
+			for _, name := range tt.extracted {
+				info := types.NewDownloadedArtifactInfo(&types.ArtifactInfo{Name: name}, name, 0)
+				if err := observer.Notify(ctx, events.NewArtifactExtractedEvent("exec", info, 1)); err != nil {
+					t.Fatalf("Notify() error = %v", err)
+				}
+			}
+			status := events.NewStatusEvent("exec")
+			for _, name := range tt.rowErrors {
+				status.OnRowError(error_types.EnsureRowError(name, error_types.RowOperationTypeMapping, errors.New("failed to map row")))
+			}
+			if err := observer.Notify(ctx, status); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			httpClient := &recordingHTTPClient{}
+			s := &AwsS3BucketSource{
+				client: s3.New(s3.Options{
+					Region:      "us-east-1",
+					Credentials: aws.AnonymousCredentials{},
+					HTTPClient:  httpClient,
+				}, func(o *s3.Options) {
+					o.RetryMaxAttempts = 1
+				}),
+				processedObjects: observer,
+			}
+			s.Config = &AwsS3BucketSourceConfig{
+				Bucket:             "bucket",
+				ProcessedObjectTag: aws.String("tailpipe-collected"),
+			}
+
+			s.applyProcessedObjectActions(ctx)
+
+			if len(httpClient.paths) != len(tt.wantPaths) {
+				t.Fatalf("requests = %v, want %v", httpClient.paths, tt.wantPaths)
+			}
+			for i, path := range tt.wantPaths {
+				if httpClient.paths[i] != path {
+					t.Errorf("requests[%d] = %q, want %q", i, httpClient.paths[i], path)
+				}
+			}
+		})
+	}
+}
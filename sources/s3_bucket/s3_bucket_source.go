@@ -10,10 +10,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/elastic/go-grok"
 
 	typehelpers "github.com/turbot/go-kit/types"
@@ -21,7 +23,9 @@ import (
 	"github.com/turbot/tailpipe-plugin-aws/config"
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
 	"github.com/turbot/tailpipe-plugin-sdk/context_values"
+	"github.com/turbot/tailpipe-plugin-sdk/observable"
 	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
 	"github.com/turbot/tailpipe-plugin-sdk/types"
 )
 
@@ -30,11 +34,18 @@ const (
 	defaultBucketRegion         = "us-east-1"
 )
 
+// s3WalkFunc is the signature of the functions used to walk a prefix of the bucket
+type s3WalkFunc func(ctx context.Context, bucket string, prefix string, layouts []string, filterMap map[string]*filter.SqlFilter, g *grok.Grok) error
+
 // AwsS3BucketSource is a [ArtifactSource] implementation that reads artifacts from an S3 bucket
 type AwsS3BucketSource struct {
 	artifact_source.ArtifactSourceImpl[*AwsS3BucketSourceConfig, *config.AwsConnection]
 
 	client *s3.Client
+	// processedObjects records the objects collected without error, for post-collection actions
+	processedObjects *processedObjectObserver
+	// preview is the report built when running in preview mode
+	preview *previewReport
 }
 
 func (s *AwsS3BucketSource) Init(ctx context.Context, params *row_source.RowSourceParams, opts ...row_source.RowSourceOption) error {
@@ -53,11 +64,31 @@ func (s *AwsS3BucketSource) Init(ctx context.Context, params *row_source.RowSour
 	}
 	s.client = client
 
+	// if post-collection actions are configured (and we are not previewing), observe our own events (and those of the collector) to determine which objects were processed
+	if s.Config.hasProcessedObjectActions() && !typehelpers.BoolValue(s.Config.Preview) {
+		s.processedObjects = &processedObjectObserver{}
+		if err := s.AddObserver(s.processedObjects); err != nil {
+			return err
+		}
+	}
+
 	slog.Info("Initialized AwsS3BucketSource", "bucket", s.Config.Bucket, "layout", s.Config.FileLayout)
 
 	return nil
 }
 
+// AddObserver adds an observer to the source.
+// If post-collection actions are configured, also observe the collector, as row errors are only recorded in its status.
+func (s *AwsS3BucketSource) AddObserver(o observable.Observer) error {
+	if err := s.ArtifactSourceImpl.AddObserver(o); err != nil {
+		return err
+	}
+	if collector, ok := o.(table.Collector); ok && s.processedObjects != nil {
+		return collector.AddObserver(s.processedObjects)
+	}
+	return nil
+}
+
 func (s *AwsS3BucketSource) Identifier() string {
 	return AwsS3BucketSourceIdentifier
 }
@@ -67,6 +98,24 @@ func (s *AwsS3BucketSource) Close() error {
 	return nil
 }
 
+// OnCollectionComplete is called once all artifacts have been collected.
+// Once the collection state has been saved, apply any post-collection actions to the processed objects.
+func (s *AwsS3BucketSource) OnCollectionComplete() error {
+	// in preview mode nothing has been collected, so do not update the collection state
+	if typehelpers.BoolValue(s.Config.Preview) {
+		return nil
+	}
+
+	if err := s.ArtifactSourceImpl.OnCollectionComplete(); err != nil {
+		return err
+	}
+
+	if s.processedObjects != nil {
+		s.applyProcessedObjectActions(context.Background())
+	}
+	return nil
+}
+
 func (s *AwsS3BucketSource) ValidateConfig() error {
 	if s.Config.Bucket == "" {
 		return fmt.Errorf("bucket is required and cannot be empty")
@@ -108,8 +157,19 @@ func (s *AwsS3BucketSource) DiscoverArtifacts(ctx context.Context) error {
 		optionalLayouts = append(optionalLayouts, newOptionalLayouts...)
 	}
 
-	// walkS3 should only return fatal errors
-	err = s.walkS3(ctx, s.Config.Bucket, prefix, optionalLayouts, filterMap, g)
+	// in preview mode, just report the objects which would be collected
+	if typehelpers.BoolValue(s.Config.Preview) {
+		return s.previewS3(ctx, prefix, optionalLayouts, filterMap, g)
+	}
+
+	// if object versions are enabled, list object versions rather than objects
+	walkFunc := s.walkS3
+	if s.Config.ObjectVersions != nil {
+		walkFunc = s.walkS3Versions
+	}
+
+	// walkFunc should only return fatal errors
+	err = walkFunc(ctx, s.Config.Bucket, prefix, optionalLayouts, filterMap, g)
 	if err != nil {
 		slog.Error("error walking S3 bucket", "bucket", s.Config.Bucket, "error", err)
 		return fmt.Errorf("%s: %s", s.Config.Bucket, err.Error())
@@ -119,11 +179,24 @@ func (s *AwsS3BucketSource) DiscoverArtifacts(ctx context.Context) error {
 }
 
 func (s *AwsS3BucketSource) DownloadArtifact(ctx context.Context, info *types.ArtifactInfo) error {
+	// if this artifact is a specific object version, the name will contain the version ID
+	key, versionId := splitVersionedArtifactName(info.Name)
+
+	input := &s3.GetObjectInput{
+		Bucket:    &s.Config.Bucket,
+		Key:       &key,
+		VersionId: versionId,
+	}
+	var optFns []func(*s3.Options)
+	verifyChecksum := typehelpers.BoolValue(s.Config.VerifyChecksum)
+	if verifyChecksum {
+		// request the object checksum - we validate this ourselves as the content is written
+		input.ChecksumMode = s3types.ChecksumModeEnabled
+		optFns = append(optFns, s3.WithAPIOptions(removeOutputChecksumValidation))
+	}
+
 	// Get the object from S3
-	getObjectOutput, err := s.client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: &s.Config.Bucket,
-		Key:    &info.Name,
-	})
+	getObjectOutput, err := s.client.GetObject(ctx, input, optFns...)
 	if err != nil {
 		slog.Error("failed to download artifact", "bucket", s.Config.Bucket, "key", info.Name, "error", err)
 		return fmt.Errorf("%s: failed to download artifact from %s", info.Name, s.Config.Bucket)
@@ -134,7 +207,11 @@ func (s *AwsS3BucketSource) DownloadArtifact(ctx context.Context, info *types.Ar
 	size := typehelpers.Int64Value(getObjectOutput.ContentLength)
 
 	// copy the object data to a temp file
-	localFilePath := path.Join(s.TempDir, info.Name)
+	// (for object versions, include the version ID in the path so that versions of the same key do not collide)
+	localFilePath := path.Join(s.TempDir, key)
+	if versionId != nil {
+		localFilePath = path.Join(s.TempDir, *versionId, key)
+	}
 	localFileDir := filepath.Dir(localFilePath)
 
 	// ensure the directory exists of the file to write to
@@ -151,13 +228,50 @@ func (s *AwsS3BucketSource) DownloadArtifact(ctx context.Context, info *types.Ar
 	}
 	defer outFile.Close()
 
+	// if verifying the checksum, also write the data to the verifier
+	var writer io.Writer = outFile
+	var verifier *objectVerifier
+	if verifyChecksum {
+		verifier = newObjectVerifier(getObjectOutput)
+		if verifier != nil {
+			writer = io.MultiWriter(outFile, verifier)
+		} else {
+			slog.Warn("no checksum available to verify artifact", "bucket", s.Config.Bucket, "key", info.Name)
+		}
+	}
+
 	// Write the data to the local file
-	_, err = io.Copy(outFile, getObjectOutput.Body)
+	_, err = io.Copy(writer, getObjectOutput.Body)
 	if err != nil {
 		slog.Error("failed to write file content", "bucket", s.Config.Bucket, "key", info.Name, "file", outFile, "error", err)
 		return fmt.Errorf("%s: failed to download artifact from %s", info.Name, s.Config.Bucket)
 	}
 
+	if verifier != nil {
+		if actual, ok := verifier.Verify(); !ok {
+			slog.Error("artifact failed integrity verification", "bucket", s.Config.Bucket, "key", info.Name, "algorithm", verifier.algorithm, "expected", verifier.expected, "actual", actual)
+			// do not leave the unverified content behind
+			_ = os.Remove(localFilePath)
+			return &ObjectIntegrityError{
+				Bucket:    s.Config.Bucket,
+				Key:       key,
+				VersionId: versionId,
+				Algorithm: verifier.algorithm,
+				Expected:  verifier.expected,
+				Actual:    actual,
+			}
+		}
+		slog.Debug("artifact passed integrity verification", "bucket", s.Config.Bucket, "key", info.Name, "algorithm", verifier.algorithm)
+	}
+
+	// verify CloudTrail digest files if configured - the results are added to the source enrichment of the artifact
+	if s.Config.verifiesCloudTrailDigests() && isCloudTrailDigestKey(key) {
+		if err := s.verifyCloudTrailDigest(ctx, info, localFilePath, getObjectOutput.Metadata); err != nil {
+			slog.Error("failed to verify CloudTrail digest", "bucket", s.Config.Bucket, "key", info.Name, "error", err)
+			return err
+		}
+	}
+
 	// notify observers of the downloaded artifact
 	return s.OnArtifactDownloaded(ctx, types.NewDownloadedArtifactInfo(info, localFilePath, size))
 }
@@ -207,28 +321,7 @@ func (s *AwsS3BucketSource) walkS3(ctx context.Context, bucket string, prefix st
 		}
 
 		// Directories
-		for _, dir := range page.CommonPrefixes {
-			dirPrefix := typehelpers.SafeString(*dir.Prefix)
-			err = s.WalkNode(ctx, dirPrefix, "", layouts, true, g, filterMap)
-			if err != nil {
-				// ignore skip dir error as this means directory isn't one we want to dive into
-				if errors.Is(err, fs.SkipDir) {
-					continue
-				}
-				// non-fatal error - log and notify
-				slog.Error("error obtaining directory info", "key", dirPrefix, "error", err)
-				s.NotifyError(ctx, executionId, fmt.Errorf("%s: failed to obtain directory info", dirPrefix))
-				err = nil
-				continue
-			}
-			err = s.walkS3(ctx, bucket, dirPrefix, layouts, filterMap, g)
-			if err != nil {
-				// non-fatal error - log and notify
-				slog.Error("error walking S3 bucket", "bucket", bucket, "prefix", dirPrefix, "error", err)
-				s.NotifyError(ctx, executionId, fmt.Errorf("%s: %s", bucket, err.Error()))
-				err = nil
-			}
-		}
+		s.walkCommonPrefixes(ctx, bucket, page.CommonPrefixes, layouts, filterMap, g, s.walkS3)
 
 		// Files
 		for _, obj := range page.Contents {
@@ -249,3 +342,51 @@ func (s *AwsS3BucketSource) walkS3(ctx context.Context, bucket string, prefix st
 
 	return nil
 }
+
+// walkCommonPrefixes descends into each directory which satisfies the layout, using walkFunc to walk its contents
+// all errors are non-fatal, so are notified rather than returned
+func (s *AwsS3BucketSource) walkCommonPrefixes(ctx context.Context, bucket string, commonPrefixes []s3types.CommonPrefix, layouts []string, filterMap map[string]*filter.SqlFilter, g *grok.Grok, walkFunc s3WalkFunc) {
+	executionId, err := context_values.ExecutionIdFromContext(ctx)
+	if err != nil {
+		slog.Error("error getting execution id", "error", err)
+		return
+	}
+
+	for _, dir := range commonPrefixes {
+		dirPrefix := typehelpers.SafeString(dir.Prefix)
+		err = s.WalkNode(ctx, dirPrefix, "", layouts, true, g, filterMap)
+		if err != nil {
+			// ignore skip dir error as this means directory isn't one we want to dive into
+			if errors.Is(err, fs.SkipDir) {
+				continue
+			}
+			// non-fatal error - log and notify
+			slog.Error("error obtaining directory info", "key", dirPrefix, "error", err)
+			s.NotifyError(ctx, executionId, fmt.Errorf("%s: failed to obtain directory info", dirPrefix))
+			continue
+		}
+		err = walkFunc(ctx, bucket, dirPrefix, layouts, filterMap, g)
+		if err != nil {
+			// non-fatal error - log and notify
+			slog.Error("error walking S3 bucket", "bucket", bucket, "prefix", dirPrefix, "error", err)
+			s.NotifyError(ctx, executionId, fmt.Errorf("%s: %s", bucket, err.Error()))
+		}
+	}
+}
+
+// inCollectionTimeRange returns whether an artifact timestamp is within the from and to time of the collection
+// (an artifact with no timestamp is always in range)
+func (s *AwsS3BucketSource) inCollectionTimeRange(timestamp time.Time) bool {
+	if timestamp.IsZero() {
+		return true
+	}
+	from := s.CollectionTimeRange.LowerBoundary
+	to := s.CollectionTimeRange.UpperBoundary
+	if !from.IsZero() && timestamp.Compare(from) < 0 {
+		return false
+	}
+	if !to.IsZero() && timestamp.Compare(to) > 0 {
+		return false
+	}
+	return true
+}
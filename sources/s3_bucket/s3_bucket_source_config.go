@@ -2,12 +2,21 @@ package s3_bucket
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	typehelpers "github.com/turbot/go-kit/types"
 
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
 )
 
+const (
+	// ObjectVersionsCurrent collects only the current version of each object, skipping delete markers
+	ObjectVersionsCurrent = "current"
+	// ObjectVersionsAll collects every version of each object
+	ObjectVersionsAll = "all"
+)
+
 // AwsS3BucketSourceConfig is the configuration for an [AwsS3BucketSource]
 type AwsS3BucketSourceConfig struct {
 	// required to allow partial decoding
@@ -16,6 +25,34 @@ type AwsS3BucketSourceConfig struct {
 
 	Bucket string  `hcl:"bucket"`
 	Prefix *string `hcl:"prefix,optional"`
+	// ObjectVersions enables version-aware collection for buckets with versioning enabled,
+	// listing objects with ListObjectVersions rather than ListObjectsV2.
+	// Valid values are "current" and "all".
+	ObjectVersions *string `hcl:"object_versions,optional"`
+	// VerifyChecksum enables verification of downloaded objects against the checksum S3 holds for them,
+	// failing the artifact if the content does not match
+	VerifyChecksum *bool `hcl:"verify_checksum,optional"`
+
+	// CloudTrailDigestPublicKeys are the CloudTrail public keys used to verify the signature of CloudTrail digest files,
+	// by fingerprint. The keys are base64 encoded, as returned by the CloudTrail ListPublicKeys API.
+	CloudTrailDigestPublicKeys map[string]string `hcl:"cloudtrail_digest_public_keys,optional"`
+	// VerifyCloudTrailDigestLogFiles enables verification of the log files and previous digest file referenced by
+	// each CloudTrail digest file, against the hashes recorded in the digest
+	VerifyCloudTrailDigestLogFiles *bool `hcl:"verify_cloudtrail_digest_log_files,optional"`
+
+	// ProcessedObjectTag is the key of a tag added to each object once it has been collected without error,
+	// with the time of collection as the value
+	ProcessedObjectTag *string `hcl:"processed_object_tag,optional"`
+	// ProcessedObjectCopyBucket and ProcessedObjectCopyPrefix specify a location each object is copied to
+	// once it has been collected without error
+	ProcessedObjectCopyBucket *string `hcl:"processed_object_copy_bucket,optional"`
+	ProcessedObjectCopyPrefix *string `hcl:"processed_object_copy_prefix,optional"`
+
+	// Preview enables preview mode - the bucket is listed and the layout and time range applied,
+	// but no objects are downloaded and the collection state is not updated
+	Preview *bool `hcl:"preview,optional"`
+	// PreviewReportPath is the path of a file the preview report is written to, as JSON
+	PreviewReportPath *string `hcl:"preview_report_path,optional"`
 }
 
 func (c *AwsS3BucketSourceConfig) Validate() error {
@@ -23,9 +60,50 @@ func (c *AwsS3BucketSourceConfig) Validate() error {
 		return fmt.Errorf("bucket is required and cannot be empty")
 	}
 
+	if c.ObjectVersions != nil {
+		switch *c.ObjectVersions {
+		case ObjectVersionsCurrent, ObjectVersionsAll:
+		default:
+			return fmt.Errorf("object_versions must be one of '%s' or '%s', got '%s'", ObjectVersionsCurrent, ObjectVersionsAll, *c.ObjectVersions)
+		}
+	}
+
+	if c.PreviewReportPath != nil && !typehelpers.BoolValue(c.Preview) {
+		return fmt.Errorf("preview_report_path can only be set when preview is enabled")
+	}
+
+	for fingerprint, key := range c.CloudTrailDigestPublicKeys {
+		if _, err := parseCloudTrailPublicKey(key); err != nil {
+			return fmt.Errorf("cloudtrail_digest_public_keys: invalid public key with fingerprint %s: %w", fingerprint, err)
+		}
+	}
+
+	if c.ProcessedObjectTag != nil && *c.ProcessedObjectTag == "" {
+		return fmt.Errorf("processed_object_tag cannot be empty")
+	}
+
+	// copying an object to itself is not allowed, so a prefix is required when copying within the bucket
+	copyBucket := c.Bucket
+	if c.ProcessedObjectCopyBucket != nil {
+		copyBucket = *c.ProcessedObjectCopyBucket
+	}
+	if c.ProcessedObjectCopyBucket != nil || c.ProcessedObjectCopyPrefix != nil {
+		if copyBucket == "" {
+			return fmt.Errorf("processed_object_copy_bucket cannot be empty")
+		}
+		if copyBucket == c.Bucket && strings.Trim(typehelpers.SafeString(c.ProcessedObjectCopyPrefix), "/") == "" {
+			return fmt.Errorf("processed_object_copy_prefix is required when copying processed objects within the source bucket")
+		}
+	}
+
 	return nil
 }
 
+// verifiesCloudTrailDigests returns whether any CloudTrail digest verification is configured
+func (c *AwsS3BucketSourceConfig) verifiesCloudTrailDigests() bool {
+	return len(c.CloudTrailDigestPublicKeys) > 0 || typehelpers.BoolValue(c.VerifyCloudTrailDigestLogFiles)
+}
+
 func (c *AwsS3BucketSourceConfig) Identifier() string {
 	return AwsS3BucketSourceIdentifier
 }
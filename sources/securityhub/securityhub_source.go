@@ -0,0 +1,189 @@
+// Package securityhub provides functionality to collect findings from AWS Security Hub
+//
+// This package uses the Security Hub GetFindings API to collect findings updated in the collection time range
+// from each configured region, supporting filtering and incremental collection.
+package securityhub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+	"golang.org/x/time/rate"
+
+	"github.com/turbot/tailpipe-plugin-aws/config"
+	"github.com/turbot/tailpipe-plugin-aws/sources/windowed_collection"
+	"github.com/turbot/tailpipe-plugin-sdk/collection_state"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	rowTypes "github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+const (
+	// AwsSecurityHubSourceIdentifier is the unique identifier for the Security Hub source
+	AwsSecurityHubSourceIdentifier = "aws_securityhub"
+
+	// findingsWindow is the duration of the time windows the collection time range is split into.
+	// The findings updated in each window are collected for all regions and sorted before being processed.
+	findingsWindow = 24 * time.Hour
+	// getFindingsRateLimit is the GetFindings API limit of 3 requests per second, per account, per region
+	getFindingsRateLimit = 3
+	// maxFindingsResults is the maximum number of findings returned by each GetFindings call
+	maxFindingsResults = 100
+)
+
+// AwsSecurityHubSource is responsible for collecting findings from the Security Hub GetFindings API.
+// It implements the RowSource interface, and tracks the collected findings by their UpdatedAt time
+// in a time range collection state.
+type AwsSecurityHubSource struct {
+	// Embeds the base RowSourceImpl with Security Hub-specific config and AWS connection.
+	row_source.RowSourceImpl[*AwsSecurityHubSourceConfig, *config.AwsConnection]
+
+	// collector holds the Security Hub client of each region, and collects the findings of all regions in time windows.
+	collector windowed_collection.Collector[*securityhub.Client, types.AwsSecurityFinding]
+	// limiters limit the rate of GetFindings calls for each region to the API limit.
+	limiters map[string]*rate.Limiter
+}
+
+// Init sets up the Security Hub source with the provided parameters and options,
+// creating a Security Hub client and rate limiter for each region.
+func (s *AwsSecurityHubSource) Init(ctx context.Context, params *row_source.RowSourceParams, opts ...row_source.RowSourceOption) error {
+	// Set up the collection state constructor
+	s.NewCollectionStateFunc = collection_state.NewTimeRangeCollectionState
+
+	// ASFF timestamps have millisecond precision
+	s.RowSourceImpl.GetGranularityFunc = s.getGranularity
+
+	// Initialize the base implementation
+	if err := s.RowSourceImpl.Init(ctx, params, opts...); err != nil {
+		return err
+	}
+
+	return s.initClients(ctx)
+}
+
+// getGranularity returns the granularity for this source type, which is set to 1 millisecond.
+func (s *AwsSecurityHubSource) getGranularity() time.Duration {
+	return time.Millisecond
+}
+
+// Identifier returns the unique identifier for this source type, used in the plugin system.
+func (s *AwsSecurityHubSource) Identifier() string {
+	return AwsSecurityHubSourceIdentifier
+}
+
+// Collect retrieves the findings updated in the collection time range.
+//
+// The time range is split into windows, and for each window:
+//  1. The findings updated in the window are retrieved for each region, with the configured filters,
+//     paginating and limiting requests to the API rate limit.
+//  2. The findings for all regions are sorted by their UpdatedAt time.
+//  3. Findings already collected are skipped based on the collection state.
+//  4. Each new finding is forwarded for processing.
+//
+// Each update of a finding is collected as a separate row, identified by the finding ID and UpdatedAt time.
+func (s *AwsSecurityHubSource) Collect(ctx context.Context) error {
+	from := s.CollectionTimeRange.StartTime()
+	to := s.CollectionTimeRange.EndTime()
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	if err := s.collector.Collect(ctx, s.CollectionState, from, to, findingsWindow, s.getFindings, s.processFinding); err != nil {
+		return fmt.Errorf("failed to get findings, %w", err)
+	}
+
+	return s.collector.Error("Security Hub")
+}
+
+// processFinding forwards a finding for processing
+func (s *AwsSecurityHubSource) processFinding(ctx context.Context, f windowed_collection.Item[types.AwsSecurityFinding]) error {
+	region := f.Region
+	row := &rowTypes.RowData{
+		Data: f.Value,
+		SourceEnrichment: &schema.SourceEnrichment{
+			CommonFields: schema.CommonFields{
+				TpSourceType:     AwsSecurityHubSourceIdentifier,
+				TpSourceLocation: &region,
+			},
+		},
+	}
+
+	return s.OnRow(ctx, row)
+}
+
+// getFindings retrieves all findings for a region updated in the given time range, handling pagination.
+// Requests are limited to the GetFindings API rate limit for the region.
+// The end of the date filter is inclusive, so findings updated at the end time are returned, and skipped by the collector.
+func (s *AwsSecurityHubSource) getFindings(ctx context.Context, region string, from, to time.Time) ([]windowed_collection.Item[types.AwsSecurityFinding], error) {
+	var findings []windowed_collection.Item[types.AwsSecurityFinding]
+
+	filters := s.Config.filters()
+	filters.UpdatedAt = []types.DateFilter{{
+		Start: aws.String(from.UTC().Format(time.RFC3339Nano)),
+		End:   aws.String(to.UTC().Format(time.RFC3339Nano)),
+	}}
+
+	input := &securityhub.GetFindingsInput{
+		Filters:    filters,
+		MaxResults: aws.Int32(maxFindingsResults),
+		SortCriteria: []types.SortCriterion{{
+			Field:     aws.String("UpdatedAt"),
+			SortOrder: types.SortOrderAscending,
+		}},
+	}
+
+	paginator := securityhub.NewGetFindingsPaginator(s.collector.Clients[region], input)
+	for paginator.HasMorePages() {
+		if err := s.limiters[region].Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, finding := range output.Findings {
+			if finding.Id == nil || finding.UpdatedAt == nil {
+				s.collector.Errors = append(s.collector.Errors, fmt.Errorf("skipping finding with no ID or UpdatedAt in region %s", region))
+				continue
+			}
+			updatedAt, err := time.Parse(time.RFC3339Nano, *finding.UpdatedAt)
+			if err != nil {
+				s.collector.Errors = append(s.collector.Errors, fmt.Errorf("skipping finding %s with invalid UpdatedAt: %w", *finding.Id, err))
+				continue
+			}
+			findings = append(findings, windowed_collection.Item[types.AwsSecurityFinding]{
+				Region: region,
+				// a finding is collected each time it is updated
+				Id:    fmt.Sprintf("%s/%s", *finding.Id, *finding.UpdatedAt),
+				Time:  updatedAt,
+				Value: finding,
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// initClients initializes an AWS Security Hub client and rate limiter for each configured region.
+// If no regions are configured, the region of the connection is used.
+func (s *AwsSecurityHubSource) initClients(ctx context.Context) error {
+	newClient := func(cfg aws.Config) *securityhub.Client {
+		return securityhub.NewFromConfig(cfg)
+	}
+	if err := s.collector.Init(ctx, s.Connection, s.Config.Regions, newClient); err != nil {
+		return err
+	}
+
+	s.limiters = make(map[string]*rate.Limiter)
+	for _, region := range s.collector.Regions {
+		s.limiters[region] = rate.NewLimiter(rate.Limit(getFindingsRateLimit), 1)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package securityhub
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+)
+
+// AwsSecurityHubSourceConfig defines the configuration parameters for collecting findings from the
+// Security Hub GetFindings API, which returns findings in the AWS Security Finding Format (ASFF).
+type AwsSecurityHubSourceConfig struct {
+	// Regions are the AWS regions to collect findings from.
+	// If not specified, the region of the connection is used.
+	// If Security Hub cross-region aggregation is enabled, use the aggregation region to collect findings for all linked regions.
+	Regions []string `hcl:"regions,optional"`
+	// RecordStates optionally filters the findings by record state, e.g. ["ACTIVE"]
+	RecordStates []string `hcl:"record_states,optional"`
+	// WorkflowStatuses optionally filters the findings by workflow status, e.g. ["NEW", "NOTIFIED"]
+	WorkflowStatuses []string `hcl:"workflow_statuses,optional"`
+	// SeverityLabels optionally filters the findings by severity label, e.g. ["HIGH", "CRITICAL"]
+	SeverityLabels []string `hcl:"severity_labels,optional"`
+}
+
+// Validate checks if the configuration is valid.
+func (c *AwsSecurityHubSourceConfig) Validate() error {
+	for _, region := range c.Regions {
+		if region == "" {
+			return fmt.Errorf("regions cannot contain an empty region")
+		}
+	}
+	if err := validateValues("record_states", c.RecordStates, types.RecordState("").Values()); err != nil {
+		return err
+	}
+	if err := validateValues("workflow_statuses", c.WorkflowStatuses, types.WorkflowStatus("").Values()); err != nil {
+		return err
+	}
+	if err := validateValues("severity_labels", c.SeverityLabels, types.SeverityLabel("").Values()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Identifier returns the unique identifier for this source type.
+func (c *AwsSecurityHubSourceConfig) Identifier() string {
+	return AwsSecurityHubSourceIdentifier
+}
+
+// filters returns the GetFindings filters for the configured record states, workflow statuses and severity labels
+func (c *AwsSecurityHubSourceConfig) filters() *types.AwsSecurityFindingFilters {
+	return &types.AwsSecurityFindingFilters{
+		RecordState:    equalsFilters(c.RecordStates),
+		WorkflowStatus: equalsFilters(c.WorkflowStatuses),
+		SeverityLabel:  equalsFilters(c.SeverityLabels),
+	}
+}
+
+func equalsFilters(values []string) []types.StringFilter {
+	var filters []types.StringFilter
+	for _, value := range values {
+		filters = append(filters, types.StringFilter{
+			Comparison: types.StringFilterComparisonEquals,
+			Value:      &value,
+		})
+	}
+	return filters
+}
+
+func validateValues[T ~string](argument string, values []string, allowed []T) error {
+	for _, value := range values {
+		if !slices.Contains(allowed, T(value)) {
+			return fmt.Errorf("invalid %s value '%s', must be one of %v", argument, value, allowed)
+		}
+	}
+	return nil
+}
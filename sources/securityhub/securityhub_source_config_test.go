@@ -0,0 +1,79 @@
+package securityhub
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+)
+
+func TestAwsSecurityHubSourceConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *AwsSecurityHubSourceConfig
+		wantErr bool
+	}{
+		{
+			name:   "no filters",
+			config: &AwsSecurityHubSourceConfig{},
+		},
+		{
+			name: "valid filters",
+			config: &AwsSecurityHubSourceConfig{
+				Regions:          []string{"us-east-1"},
+				RecordStates:     []string{"ACTIVE"},
+				WorkflowStatuses: []string{"NEW", "NOTIFIED"},
+				SeverityLabels:   []string{"HIGH", "CRITICAL"},
+			},
+		},
+		{
+			name:    "empty region",
+			config:  &AwsSecurityHubSourceConfig{Regions: []string{""}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid record state",
+			config:  &AwsSecurityHubSourceConfig{RecordStates: []string{"active"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid workflow status",
+			config:  &AwsSecurityHubSourceConfig{WorkflowStatuses: []string{"OPEN"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid severity label",
+			config:  &AwsSecurityHubSourceConfig{SeverityLabels: []string{"SEVERE"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAwsSecurityHubSourceConfig_Filters(t *testing.T) {
+	config := &AwsSecurityHubSourceConfig{
+		SeverityLabels: []string{"HIGH", "CRITICAL"},
+	}
+
+	filters := config.filters()
+
+	if len(filters.RecordState) != 0 || len(filters.WorkflowStatus) != 0 {
+		t.Errorf("expected no record state or workflow status filters, got %v, %v", filters.RecordState, filters.WorkflowStatus)
+	}
+	if len(filters.SeverityLabel) != 2 {
+		t.Fatalf("expected 2 severity label filters, got %d", len(filters.SeverityLabel))
+	}
+	for i, want := range []string{"HIGH", "CRITICAL"} {
+		filter := filters.SeverityLabel[i]
+		if filter.Comparison != types.StringFilterComparisonEquals || *filter.Value != want {
+			t.Errorf("unexpected severity label filter %d: %s %s", i, filter.Comparison, *filter.Value)
+		}
+	}
+}
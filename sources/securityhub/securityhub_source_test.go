@@ -0,0 +1,93 @@
+package securityhub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	"golang.org/x/time/rate"
+)
+
+// getFindingsHTTPClient records the GetFindings request, responding with a single page of findings
+type getFindingsHTTPClient struct {
+	request  map[string]any
+	findings []map[string]any
+}
+
+func (c *getFindingsHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if err := json.NewDecoder(req.Body).Decode(&c.request); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(map[string]any{"Findings": c.findings})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func TestGetFindings(t *testing.T) {
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(findingsWindow)
+
+	httpClient := &getFindingsHTTPClient{
+		findings: []map[string]any{
+			{"Id": "a", "UpdatedAt": "2025-04-01T10:20:30.123Z"},
+			{"Id": "b", "UpdatedAt": "yesterday"},
+			{"Id": "c"},
+		},
+	}
+
+	s := &AwsSecurityHubSource{}
+	s.Config = &AwsSecurityHubSourceConfig{SeverityLabels: []string{"HIGH", "CRITICAL"}}
+	s.collector.Clients = map[string]*securityhub.Client{
+		"us-east-1": securityhub.New(securityhub.Options{
+			Region:      "us-east-1",
+			Credentials: aws.AnonymousCredentials{},
+			HTTPClient:  httpClient,
+		}),
+	}
+	s.limiters = map[string]*rate.Limiter{"us-east-1": rate.NewLimiter(rate.Inf, 1)}
+
+	findings, err := s.getFindings(context.Background(), "us-east-1", from, to)
+	if err != nil {
+		t.Fatalf("getFindings() error = %v", err)
+	}
+
+	// the configured filters are combined with the UpdatedAt filter for the time range
+	filters, _ := httpClient.request["Filters"].(map[string]any)
+	wantFilters := map[string]any{
+		"SeverityLabel": []any{
+			map[string]any{"Comparison": "EQUALS", "Value": "HIGH"},
+			map[string]any{"Comparison": "EQUALS", "Value": "CRITICAL"},
+		},
+		"UpdatedAt": []any{
+			map[string]any{"Start": "2025-04-01T00:00:00Z", "End": "2025-04-02T00:00:00Z"},
+		},
+	}
+	if !reflect.DeepEqual(filters, wantFilters) {
+		t.Errorf("filters = %v, want %v", filters, wantFilters)
+	}
+
+	// findings with no or an invalid UpdatedAt are skipped
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1", len(findings))
+	}
+	wantTime := time.Date(2025, 4, 1, 10, 20, 30, 123000000, time.UTC)
+	if f := findings[0]; f.Id != "a/2025-04-01T10:20:30.123Z" || !f.Time.Equal(wantTime) {
+		t.Errorf("finding = %s %s, want a/2025-04-01T10:20:30.123Z %s", f.Id, f.Time, wantTime)
+	}
+	if len(s.collector.Errors) != 2 {
+		t.Errorf("errors = %v, want 2 errors", s.collector.Errors)
+	}
+}
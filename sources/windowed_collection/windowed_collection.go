@@ -0,0 +1,152 @@
+// Package windowed_collection provides functionality shared by the sources which collect items, such as events or
+// findings, from an AWS API for multiple regions
+//
+// The collection time range is split into windows, and the items of each window are collected for all regions and
+// sorted before being processed, as the time range collection state requires items to be collected chronologically.
+// Items already collected are skipped based on the collection state.
+package windowed_collection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/turbot/tailpipe-plugin-aws/config"
+	"github.com/turbot/tailpipe-plugin-sdk/collection_state"
+)
+
+// Item is an item returned by an AWS API, with the region it was returned for
+type Item[T any] struct {
+	// Region is the region the item was returned for.
+	Region string
+	// Id uniquely identifies the item in the collection state.
+	Id string
+	// Time is the time of the item used to sort the items and track them in the collection state.
+	Time time.Time
+	// Value is the item returned by the API.
+	Value T
+}
+
+// FetchFunc retrieves the items of a region in the time range from-to.
+type FetchFunc[T any] func(ctx context.Context, region string, from, to time.Time) ([]Item[T], error)
+
+// RowFunc forwards an item for processing as a row.
+type RowFunc[T any] func(ctx context.Context, item Item[T]) error
+
+// Collector collects the items returned by an AWS API for multiple regions, using a client of type C for each region.
+type Collector[C, T any] struct {
+	// Clients are the AWS clients used for API calls, keyed by region.
+	Clients map[string]C
+	// Regions are the regions to collect, in a consistent order.
+	Regions []string
+	// Errors accumulates errors encountered during collection for reporting.
+	Errors []error
+}
+
+// Init creates a client for each of the given regions using newClient.
+// If no regions are given, the region of the connection is used.
+func (c *Collector[C, T]) Init(ctx context.Context, conn *config.AwsConnection, regions []string, newClient func(cfg aws.Config) C) error {
+	c.Clients = make(map[string]C)
+	c.Regions = nil
+	c.Errors = []error{}
+
+	if len(regions) == 0 {
+		cfg, err := conn.GetClientConfiguration(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get client configuration, %w", err)
+		}
+		regions = []string{cfg.Region}
+	}
+
+	for _, region := range regions {
+		if _, ok := c.Clients[region]; ok {
+			continue
+		}
+		cfg, err := conn.GetClientConfiguration(ctx, &region)
+		if err != nil {
+			return fmt.Errorf("failed to get client configuration for region %s, %w", region, err)
+		}
+		c.Clients[region] = newClient(*cfg)
+		c.Regions = append(c.Regions, region)
+	}
+
+	return nil
+}
+
+// Collect splits the time range from-to into windows of the given duration, and for each window:
+//  1. The items of each region are retrieved with fetch.
+//  2. Items at or after the end of the window are skipped, as they belong to the next window.
+//  3. The items of all regions are sorted chronologically.
+//  4. Items already collected are skipped based on the collection state.
+//  5. Each new item is recorded in the collection state and passed to onRow.
+//
+// Collection stops at the first window which cannot be retrieved, as later items must not be collected
+// while this window is incomplete.
+func (c *Collector[C, T]) Collect(ctx context.Context, state *collection_state.SaveableCollectionState, from, to time.Time, window time.Duration, fetch FetchFunc[T], onRow RowFunc[T]) error {
+	for windowStart := from; windowStart.Before(to); windowStart = windowStart.Add(window) {
+		windowEnd := windowStart.Add(window)
+		if windowEnd.After(to) {
+			windowEnd = to
+		}
+
+		var items []Item[T]
+		for _, region := range c.Regions {
+			regionItems, err := fetch(ctx, region, windowStart, windowEnd)
+			if err != nil {
+				return fmt.Errorf("region %s: %w", region, err)
+			}
+			for _, item := range regionItems {
+				// the end of the time range of some APIs is inclusive
+				if !item.Time.Before(windowEnd) {
+					continue
+				}
+				items = append(items, item)
+			}
+		}
+
+		slog.Debug("Retrieved items", "from", windowStart, "to", windowEnd, "count", len(items))
+
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Time.Before(items[j].Time)
+		})
+
+		for _, item := range items {
+			c.collectItem(ctx, state, item, onRow)
+		}
+	}
+
+	return nil
+}
+
+// collectItem passes an item to onRow, if it has not already been collected
+func (c *Collector[C, T]) collectItem(ctx context.Context, state *collection_state.SaveableCollectionState, item Item[T], onRow RowFunc[T]) {
+	// Skip already collected items based on state
+	if !state.ShouldCollect(item.Id, item.Time) {
+		slog.Debug("Skipping already collected item", "id", item.Id, "timestamp", item.Time.Format(time.RFC3339Nano))
+		return
+	}
+
+	// Update collection state with the processed item
+	if err := state.OnCollected(item.Id, item.Time); err != nil {
+		c.Errors = append(c.Errors, fmt.Errorf("failed to update collection state for %s: %w", item.Id, err))
+		return
+	}
+
+	// Send the row for processing
+	if err := onRow(ctx, item); err != nil {
+		c.Errors = append(c.Errors, fmt.Errorf("error processing %s: %w", item.Id, err))
+	}
+}
+
+// Error returns an error reporting the errors accumulated during the collection, or nil if there were none.
+// The description identifies the collection, e.g. "GuardDuty".
+func (c *Collector[C, T]) Error(description string) error {
+	if len(c.Errors) > 0 {
+		return fmt.Errorf("encountered %d errors during %s collection: %v", len(c.Errors), description, c.Errors)
+	}
+	return nil
+}
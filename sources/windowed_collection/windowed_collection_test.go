@@ -0,0 +1,127 @@
+package windowed_collection
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/collection_state"
+)
+
+func TestCollectorCollect(t *testing.T) {
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		items     map[string][]string
+		collected []string
+		failAt    time.Time
+		wantItems []string
+		wantErr   bool
+	}{
+		{
+			name: "items of all regions sorted within each window",
+			items: map[string][]string{
+				"us-east-1": {"00:30", "01:10"},
+				"eu-west-1": {"00:10", "01:50"},
+			},
+			wantItems: []string{"eu-west-1 00:10", "us-east-1 00:30", "us-east-1 01:10", "eu-west-1 01:50"},
+		},
+		{
+			name: "items at the end of the window skipped",
+			items: map[string][]string{
+				"us-east-1": {"00:59", "01:00", "02:00"},
+			},
+			// 01:00 is returned for both windows, but only collected in the second
+			wantItems: []string{"us-east-1 00:59", "us-east-1 01:00"},
+		},
+		{
+			name: "already collected items skipped",
+			items: map[string][]string{
+				"us-east-1": {"00:10", "00:20", "01:30"},
+			},
+			collected: []string{"us-east-1 00:10", "us-east-1 00:20"},
+			wantItems: []string{"us-east-1 01:30"},
+		},
+		{
+			name: "collection stopped at a failed window",
+			items: map[string][]string{
+				"us-east-1": {"00:30", "01:30"},
+			},
+			failAt:    from.Add(time.Hour),
+			wantItems: []string{"us-east-1 00:30"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Collector[struct{}, string]{Regions: []string{"us-east-1", "eu-west-1"}}
+
+			itemTime := func(clock string) time.Time {
+				offset, err := time.Parse("15:04", clock)
+				if err != nil {
+					t.Fatalf("invalid item time: %v", err)
+				}
+				return from.Add(time.Duration(offset.Hour())*time.Hour + time.Duration(offset.Minute())*time.Minute)
+			}
+
+			state, err := collection_state.NewSaveableCollectionState(collection_state.NewTimeRangeCollectionState(), filepath.Join(t.TempDir(), "state.json"))
+			if err != nil {
+				t.Fatalf("NewSaveableCollectionState() error = %v", err)
+			}
+			// the state time range extends past the collected time range, so that items at the end of the
+			// time range are only excluded by the collector
+			timeRange := collection_state.DirectionalTimeRange{
+				LowerBoundary:   from,
+				UpperBoundary:   to.Add(time.Hour),
+				CollectionOrder: collection_state.CollectionOrderChronological,
+			}
+			if err := state.Init(timeRange, false, time.Second); err != nil {
+				t.Fatalf("Init() error = %v", err)
+			}
+			for _, id := range tt.collected {
+				timestamp := itemTime(id[len(id)-5:])
+				if !state.ShouldCollect(id, timestamp) {
+					t.Fatalf("item %s not collectable", id)
+				}
+				if err := state.OnCollected(id, timestamp); err != nil {
+					t.Fatalf("OnCollected() error = %v", err)
+				}
+			}
+
+			// return the items of the region in the window, including the end of the window
+			fetch := func(_ context.Context, region string, windowFrom, windowTo time.Time) ([]Item[string], error) {
+				if windowFrom.Equal(tt.failAt) {
+					return nil, errors.New("request failed")
+				}
+				var res []Item[string]
+				for _, clock := range tt.items[region] {
+					if timestamp := itemTime(clock); !timestamp.Before(windowFrom) && !timestamp.After(windowTo) {
+						id := region + " " + clock
+						res = append(res, Item[string]{Region: region, Id: id, Time: timestamp, Value: id})
+					}
+				}
+				return res, nil
+			}
+
+			var got []string
+			onRow := func(_ context.Context, item Item[string]) error {
+				got = append(got, item.Value)
+				return nil
+			}
+
+			err = c.Collect(context.Background(), state, from, to, time.Hour, fetch, onRow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Collect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.wantItems) {
+				t.Errorf("collected items = %v, want %v", got, tt.wantItems)
+			}
+		})
+	}
+}
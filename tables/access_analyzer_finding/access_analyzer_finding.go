@@ -0,0 +1,67 @@
+package access_analyzer_finding
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type AccessAnalyzerFinding struct {
+	schema.CommonFields
+
+	AccountId                        *string                  `json:"account_id,omitempty"`
+	Action                           []string                 `json:"action,omitempty"`
+	AnalyzedAt                       *time.Time               `json:"analyzed_at,omitempty"`
+	Condition                        *map[string]interface{}  `json:"condition,omitempty" parquet:"type=JSON"`
+	CreatedAt                        *time.Time               `json:"created_at,omitempty"`
+	DetailType                       *string                  `json:"detail_type,omitempty"`
+	Error                            *string                  `json:"error,omitempty"`
+	EventId                          *string                  `json:"event_id,omitempty"`
+	EventTime                        *time.Time               `json:"event_time,omitempty"`
+	FindingId                        *string                  `json:"finding_id,omitempty"`
+	FindingType                      *string                  `json:"finding_type,omitempty"`
+	IsDeleted                        *bool                    `json:"is_deleted,omitempty"`
+	IsPublic                         *bool                    `json:"is_public,omitempty"`
+	Principal                        *map[string]interface{}  `json:"principal,omitempty" parquet:"type=JSON"`
+	Region                           *string                  `json:"region,omitempty"`
+	Resource                         *string                  `json:"resource,omitempty"`
+	ResourceControlPolicyRestriction *string                  `json:"resource_control_policy_restriction,omitempty"`
+	ResourceOwnerAccount             *string                  `json:"resource_owner_account,omitempty"`
+	ResourceType                     *string                  `json:"resource_type,omitempty"`
+	Sources                          []map[string]interface{} `json:"sources,omitempty" parquet:"type=JSON"`
+	Status                           *string                  `json:"status,omitempty"`
+	UpdatedAt                        *time.Time               `json:"updated_at,omitempty"`
+	Version                          *string                  `json:"version,omitempty"`
+}
+
+func (f *AccessAnalyzerFinding) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"account_id":                          "The AWS account ID of the resource, or of the IAM role or user for unused access findings.",
+		"action":                              "The actions that the external principal is allowed to perform on the resource.",
+		"analyzed_at":                         "The date and time that the resource was last analyzed.",
+		"condition":                           "The conditions in the resource policy that grant access to the external principal.",
+		"created_at":                          "The date and time that the finding was created.",
+		"detail_type":                         "The detail type of the EventBridge event, which identifies the kind of finding (external, unused or internal access).",
+		"error":                               "The error that occurred when analyzing the resource, if any.",
+		"event_id":                            "The unique identifier of the EventBridge event.",
+		"event_time":                          "The date and time of the EventBridge event.",
+		"finding_id":                          "The unique identifier of the finding.",
+		"finding_type":                        "The type of the finding, e.g. ExternalAccess, UnusedIAMRole, UnusedIAMUserAccessKey, UnusedPermission or InternalAccess.",
+		"is_deleted":                          "Whether the resource of the finding has been deleted.",
+		"is_public":                           "Whether the resource is publicly accessible.",
+		"principal":                           "The external principal that has access to the resource, e.g. {\"AWS\": \"111122223333\"}.",
+		"region":                              "The AWS region of the resource.",
+		"resource":                            "The resource that the finding applies to, usually its ARN.",
+		"resource_control_policy_restriction": "Whether the access is restricted by a resource control policy (RCP).",
+		"resource_owner_account":              "The AWS account ID that owns the resource.",
+		"resource_type":                       "The type of the resource, e.g. AWS::S3::Bucket or AWS::IAM::Role.",
+		"sources":                             "The sources of the access, e.g. the bucket policy, bucket ACL or S3 access point.",
+		"status":                              "The status of the finding (ACTIVE, ARCHIVED or RESOLVED).",
+		"updated_at":                          "The date and time that the finding was last updated.",
+		"version":                             "The version of the event detail schema.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARN of the resource and the identifiers extracted from it, e.g. the bucket name, and the account ID that owns the resource.",
+		"tp_timestamp": "The date and time that the finding was last updated.",
+	}
+}
@@ -0,0 +1,131 @@
+package access_analyzer_finding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+const accessAnalyzerEventSource = "aws.access-analyzer"
+
+// AccessAnalyzerFindingExtractor is an extractor that receives a sequence of JSON serialised IAM Access Analyzer
+// EventBridge events and extracts an AccessAnalyzerFinding record from each finding event
+type AccessAnalyzerFindingExtractor struct {
+}
+
+// NewAccessAnalyzerFindingExtractor creates a new AccessAnalyzerFindingExtractor
+func NewAccessAnalyzerFindingExtractor() artifact_source.Extractor {
+	return &AccessAnalyzerFindingExtractor{}
+}
+
+func (c *AccessAnalyzerFindingExtractor) Identifier() string {
+	return "access_analyzer_finding_extractor"
+}
+
+// Extract decodes each event in the artifact data and returns the AccessAnalyzerFinding records,
+// skipping any events which are not from IAM Access Analyzer
+func (c *AccessAnalyzerFindingExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	objects, err := tables.DecodeJSONObjects(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []any
+	for _, object := range objects {
+		var event tables.EventBridgeEvent
+		if err := json.Unmarshal(object, &event); err != nil {
+			return nil, fmt.Errorf("error decoding event: %w", err)
+		}
+		if event.Source == nil || *event.Source != accessAnalyzerEventSource || len(event.Detail) == 0 {
+			continue
+		}
+
+		var detail findingDetail
+		if err := json.Unmarshal(event.Detail, &detail); err != nil {
+			return nil, fmt.Errorf("error decoding finding: %w", err)
+		}
+		// events of IAM Access Analyzer which are not findings, e.g. for analyzers, have no finding ID
+		if detail.Id == nil && detail.FindingId == nil {
+			continue
+		}
+
+		res = append(res, detail.toRow(event))
+	}
+
+	slog.Debug("AccessAnalyzerFindingExtractor", "record count", len(res))
+	return res, nil
+}
+
+// findingDetail is the detail of an IAM Access Analyzer finding EventBridge event
+type findingDetail struct {
+	AccountId                        *string                  `json:"accountId"`
+	Action                           []string                 `json:"action"`
+	AnalyzedAt                       *time.Time               `json:"analyzedAt"`
+	Condition                        *map[string]interface{}  `json:"condition"`
+	CreatedAt                        *time.Time               `json:"createdAt"`
+	Error                            *string                  `json:"error"`
+	FindingId                        *string                  `json:"findingId"`
+	FindingType                      *string                  `json:"findingType"`
+	Id                               *string                  `json:"id"`
+	IsDeleted                        *bool                    `json:"isDeleted"`
+	IsPublic                         *bool                    `json:"isPublic"`
+	Principal                        *map[string]interface{}  `json:"principal"`
+	Region                           *string                  `json:"region"`
+	Resource                         *string                  `json:"resource"`
+	ResourceControlPolicyRestriction *string                  `json:"resourceControlPolicyRestriction"`
+	ResourceOwnerAccount             *string                  `json:"resourceOwnerAccount"`
+	ResourceType                     *string                  `json:"resourceType"`
+	Sources                          []map[string]interface{} `json:"sources"`
+	Status                           *string                  `json:"status"`
+	UpdatedAt                        *time.Time               `json:"updatedAt"`
+	Version                          *string                  `json:"version"`
+}
+
+func (d *findingDetail) toRow(event tables.EventBridgeEvent) *AccessAnalyzerFinding {
+	row := &AccessAnalyzerFinding{
+		AccountId:                        d.AccountId,
+		Action:                           d.Action,
+		AnalyzedAt:                       d.AnalyzedAt,
+		Condition:                        d.Condition,
+		CreatedAt:                        d.CreatedAt,
+		DetailType:                       event.DetailType,
+		Error:                            d.Error,
+		EventId:                          event.Id,
+		EventTime:                        event.Time,
+		FindingId:                        d.Id,
+		FindingType:                      d.FindingType,
+		IsDeleted:                        d.IsDeleted,
+		IsPublic:                         d.IsPublic,
+		Principal:                        d.Principal,
+		Region:                           d.Region,
+		Resource:                         d.Resource,
+		ResourceControlPolicyRestriction: d.ResourceControlPolicyRestriction,
+		ResourceOwnerAccount:             d.ResourceOwnerAccount,
+		ResourceType:                     d.ResourceType,
+		Sources:                          d.Sources,
+		Status:                           d.Status,
+		UpdatedAt:                        d.UpdatedAt,
+		Version:                          d.Version,
+	}
+	if row.FindingId == nil {
+		row.FindingId = d.FindingId
+	}
+	// the account and region are not always set in the detail, so fall back to those of the event
+	if row.AccountId == nil {
+		row.AccountId = event.Account
+	}
+	if row.Region == nil {
+		row.Region = event.Region
+	}
+	return row
+}
@@ -0,0 +1,90 @@
+package access_analyzer_finding
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const AccessAnalyzerFindingTableIdentifier = "aws_access_analyzer_finding"
+
+// AccessAnalyzerFindingTable - table for IAM Access Analyzer findings
+type AccessAnalyzerFindingTable struct{}
+
+func (c *AccessAnalyzerFindingTable) Identifier() string {
+	return AccessAnalyzerFindingTableIdentifier
+}
+
+func (c *AccessAnalyzerFindingTable) GetSourceMetadata() ([]*table.SourceMetadata[*AccessAnalyzerFinding], error) {
+	// IAM Access Analyzer findings are delivered by an EventBridge rule
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer(tables.FirehoseDefaultFileLayout),
+	}
+
+	return []*table.SourceMetadata[*AccessAnalyzerFinding]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewAccessAnalyzerFindingExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewAccessAnalyzerFindingExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *AccessAnalyzerFindingTable) EnrichRow(row *AccessAnalyzerFinding, sourceEnrichmentFields schema.SourceEnrichment) (*AccessAnalyzerFinding, error) {
+	// use the time the finding was updated, so that each status transition is recorded at the time it was made
+	var timestamp *time.Time
+	for _, t := range []*time.Time{row.UpdatedAt, row.EventTime, row.CreatedAt} {
+		if t != nil {
+			timestamp = t
+			break
+		}
+	}
+	if timestamp == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"updatedAt"}, nil)
+	}
+
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *timestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = timestamp.Truncate(24 * time.Hour)
+
+	if row.Resource != nil && strings.HasPrefix(*row.Resource, "arn:") {
+		row.TpAkas = append(row.TpAkas, tables.AwsAkasFromArn(*row.Resource)...)
+	}
+	if row.ResourceOwnerAccount != nil && !slices.Contains(row.TpAkas, *row.ResourceOwnerAccount) {
+		row.TpAkas = append(row.TpAkas, *row.ResourceOwnerAccount)
+	}
+
+	return row, nil
+}
+
+func (c *AccessAnalyzerFindingTable) GetDescription() string {
+	return "IAM Access Analyzer findings report resources that are shared with external principals, unused IAM roles, users and permissions, and internal access to critical resources. This table provides the resource, principal, actions, conditions and status of each finding as delivered by EventBridge, allowing public and cross-account exposure to be tracked over time."
+}
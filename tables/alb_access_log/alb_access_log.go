@@ -6,11 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
 type AlbAccessLog struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	ActionsExecuted        []string  `json:"actions_executed,omitempty"`
 	ChosenCertArn          string    `json:"chosen_cert_arn,omitempty"`
@@ -179,7 +181,7 @@ func (l *AlbAccessLog) InitialiseFromMap(m map[string]string) error {
 	return nil
 }
 func (c *AlbAccessLog) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"actions_executed":         "The actions taken when processing the request, such as forwarding, redirecting, or fixed responses.",
 		"chosen_cert_arn":          "The ARN of the certificate presented to the client during the TLS handshake.",
 		"classification":           "The classification for desync mitigation, indicating compliance with RFC 7230.",
@@ -219,5 +221,5 @@ func (c *AlbAccessLog) GetColumnDescriptions() map[string]string {
 		"tp_ips":     "A list of IP addresses involved in the request, including the client IP and target IP.",
 		"tp_domains": "A list of domains involved in the request, including the SNI domain from TLS connections.",
 		"tp_akas":    "A list of AWS ARNs associated with the request, including the target group ARN.",
-	}
+	})
 }
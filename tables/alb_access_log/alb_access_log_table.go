@@ -54,6 +54,7 @@ func (c *AlbAccessLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*AlbAcc
 
 func (c *AlbAccessLogTable) EnrichRow(row *AlbAccessLog, sourceEnrichmentFields schema.SourceEnrichment) (*AlbAccessLog, error) {
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	// Record standardization
 	row.TpID = xid.New().String()
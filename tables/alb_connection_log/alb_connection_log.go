@@ -6,12 +6,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
 // AlbConnectionLog represents a connection log entry from an AWS ALB.
 type AlbConnectionLog struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	ClientIP                   string    `json:"client_ip"`
 	ClientPort                 int       `json:"client_port"`
@@ -83,7 +85,7 @@ func (c *AlbConnectionLog) InitialiseFromMap(m map[string]string) error {
 
 // GetColumnDescriptions returns a mapping of connection log field names to their descriptions.
 func (c *AlbConnectionLog) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"client_ip":                      "The IP address of the requesting client.",
 		"client_port":                    "The port of the requesting client.",
 		"conn_trace_id":                  "A unique identifier linking connection logs to subsequent access logs for the same connection.",
@@ -99,5 +101,5 @@ func (c *AlbConnectionLog) GetColumnDescriptions() map[string]string {
 
 		// Tailpipe-specific metadata fields
 		"tp_ips":   "The IP addresses involved in requesting client.",
-	}
+	})
 }
@@ -53,6 +53,7 @@ func (c *AlbConnectionLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*Al
 
 func (c *AlbConnectionLogTable) EnrichRow(row *AlbConnectionLog, sourceEnrichmentFields schema.SourceEnrichment) (*AlbConnectionLog, error) {
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	row.TpID = xid.New().String()
 	row.TpIngestTimestamp = time.Now()
@@ -0,0 +1,163 @@
+package api_gateway_access_log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/turbot/tailpipe-plugin-sdk/formats"
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+// contextVariableRegex matches a $context variable in an access log format template, e.g. $context.identity.sourceIp
+var contextVariableRegex = regexp.MustCompile(`\$context\.([A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)`)
+
+// jsonFieldRegex matches a JSON key whose value is a $context variable, e.g. "ip": "$context.identity.sourceIp"
+var jsonFieldRegex = regexp.MustCompile(`"([^"]+)"\s*:\s*"?\$context\.([A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)"?`)
+
+type ApiGatewayAccessLogTableFormat struct {
+	// the name of this format instance
+	Name string `hcl:"name,label"`
+	// Description of the format
+	Description string `hcl:"description,optional"`
+	// the layout of the log line - the $context template used by the API stage access log settings
+	Layout string `hcl:"layout"`
+}
+
+func NewApiGatewayAccessLogTableFormat() formats.Format {
+	return &ApiGatewayAccessLogTableFormat{}
+}
+
+func (a *ApiGatewayAccessLogTableFormat) Validate() error {
+	if !contextVariableRegex.MatchString(a.Layout) {
+		return fmt.Errorf("layout must contain at least one $context variable")
+	}
+	if a.isJSON() && len(jsonFieldRegex.FindAllStringSubmatch(a.Layout, -1)) == 0 {
+		return fmt.Errorf("JSON layout must contain at least one field whose value is a $context variable")
+	}
+	return nil
+}
+
+// Identifier returns the format TYPE
+func (a *ApiGatewayAccessLogTableFormat) Identifier() string {
+	// format name is same as table name
+	return ApiGatewayAccessLogTableIdentifier
+}
+
+// GetName returns the format instance name
+func (a *ApiGatewayAccessLogTableFormat) GetName() string {
+	return a.Name
+}
+
+// SetName sets the name of this format instance
+func (a *ApiGatewayAccessLogTableFormat) SetName(name string) {
+	a.Name = name
+}
+
+func (a *ApiGatewayAccessLogTableFormat) GetDescription() string {
+	return a.Description
+}
+
+// GetMapper returns a mapper for the layout - JSON layouts are decoded as JSON,
+// all other layouts (CLF, XML, CSV or any custom layout) are matched using the regex generated from the layout
+func (a *ApiGatewayAccessLogTableFormat) GetMapper() (mappers.Mapper[*types.DynamicRow], error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	if a.isJSON() {
+		return NewApiGatewayAccessLogMapper(nil, a.getJSONFields()), nil
+	}
+
+	regex, err := a.GetRegex()
+	if err != nil {
+		return nil, err
+	}
+	regexMapper, err := mappers.NewRegexMapper[*types.DynamicRow](regex)
+	if err != nil {
+		return nil, err
+	}
+	return NewApiGatewayAccessLogMapper(regexMapper, nil), nil
+}
+
+// GetRegex converts the layout to a regex, with a named capture group for each $context variable
+// and all other text in the layout matched literally
+func (a *ApiGatewayAccessLogTableFormat) GetRegex() (string, error) {
+	// validate checks the layout contains at least one variable
+	err := a.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	layout := strings.TrimSpace(a.Layout)
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	// a column may only be captured once, so any repeated variable is matched without capturing
+	captured := make(map[string]struct{})
+	last := 0
+	for _, match := range contextVariableRegex.FindAllStringSubmatchIndex(layout, -1) {
+		sb.WriteString(regexp.QuoteMeta(layout[last:match[0]]))
+
+		columnName := contextVariableColumnName(layout[match[2]:match[3]])
+		if _, ok := captured[columnName]; ok {
+			sb.WriteString(".*?")
+		} else {
+			captured[columnName] = struct{}{}
+			sb.WriteString(fmt.Sprintf("(?P<%s>.*?)", columnName))
+		}
+		last = match[1]
+	}
+	sb.WriteString(regexp.QuoteMeta(layout[last:]))
+	sb.WriteString("$")
+
+	return sb.String(), nil
+}
+
+func (a *ApiGatewayAccessLogTableFormat) GetProperties() map[string]string {
+	return map[string]string{
+		"layout": a.Layout,
+	}
+}
+
+func (a *ApiGatewayAccessLogTableFormat) isJSON() bool {
+	return strings.HasPrefix(strings.TrimSpace(a.Layout), "{")
+}
+
+// getJSONFields returns a map of the JSON keys of a JSON layout to the column name of the $context variable they contain
+func (a *ApiGatewayAccessLogTableFormat) getJSONFields() map[string]string {
+	res := make(map[string]string)
+	for _, match := range jsonFieldRegex.FindAllStringSubmatch(a.Layout, -1) {
+		res[match[1]] = contextVariableColumnName(match[2])
+	}
+	return res
+}
+
+// contextVariableColumnName converts the path of a $context variable to a column name,
+// e.g. identity.sourceIp becomes identity_source_ip
+func contextVariableColumnName(path string) string {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		parts[i] = toSnakeCase(part)
+	}
+	return strings.Join(parts, "_")
+}
+
+// toSnakeCase converts a camel case name to snake case, e.g. requestTimeEpoch becomes request_time_epoch
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// start a new word unless this continues an acronym, e.g. the "D" in "ID"
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) && runes[i-1] != '_' {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
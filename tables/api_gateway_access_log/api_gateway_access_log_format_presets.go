@@ -0,0 +1,38 @@
+package api_gateway_access_log
+
+import sdkformats "github.com/turbot/tailpipe-plugin-sdk/formats"
+
+// the presets are the access log formats offered by the API Gateway console
+
+var clfApiGatewayAccessLogTableFormat = &ApiGatewayAccessLogTableFormat{
+	Name:        "clf",
+	Description: "The Common Log Format (CLF) access log format.",
+	Layout:      `$context.identity.sourceIp $context.identity.caller $context.identity.user [$context.requestTime] "$context.httpMethod $context.resourcePath $context.protocol" $context.status $context.responseLength $context.requestId`,
+}
+
+var jsonApiGatewayAccessLogTableFormat = &ApiGatewayAccessLogTableFormat{
+	Name:        "json",
+	Description: "The JSON access log format.",
+	Layout:      `{ "requestId":"$context.requestId", "ip": "$context.identity.sourceIp", "caller":"$context.identity.caller", "user":"$context.identity.user", "requestTime":"$context.requestTime", "httpMethod":"$context.httpMethod", "resourcePath":"$context.resourcePath", "status":"$context.status", "protocol":"$context.protocol", "responseLength":"$context.responseLength" }`,
+}
+
+var xmlApiGatewayAccessLogTableFormat = &ApiGatewayAccessLogTableFormat{
+	Name:        "xml",
+	Description: "The XML access log format.",
+	Layout:      `<request id="$context.requestId"> <ip>$context.identity.sourceIp</ip> <caller>$context.identity.caller</caller> <user>$context.identity.user</user> <requestTime>$context.requestTime</requestTime> <httpMethod>$context.httpMethod</httpMethod> <resourcePath>$context.resourcePath</resourcePath> <status>$context.status</status> <protocol>$context.protocol</protocol> <responseLength>$context.responseLength</responseLength> </request>`,
+}
+
+var csvApiGatewayAccessLogTableFormat = &ApiGatewayAccessLogTableFormat{
+	Name:        "csv",
+	Description: "The CSV access log format.",
+	Layout:      `$context.identity.sourceIp,$context.identity.caller,$context.identity.user,$context.requestTime,$context.httpMethod,$context.resourcePath,$context.protocol,$context.status,$context.responseLength,$context.requestId`,
+}
+
+var defaultApiGatewayAccessLogTableFormat = jsonApiGatewayAccessLogTableFormat
+
+var ApiGatewayAccessLogTableFormatPresets = []sdkformats.Format{
+	clfApiGatewayAccessLogTableFormat,
+	jsonApiGatewayAccessLogTableFormat,
+	xmlApiGatewayAccessLogTableFormat,
+	csvApiGatewayAccessLogTableFormat,
+}
@@ -0,0 +1,107 @@
+package api_gateway_access_log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+// ApiGatewayAccessLogMapper maps an API Gateway access log entry, read from a CloudWatch log group
+// or an artifact, to a DynamicRow.
+// JSON log entries are decoded as JSON, using the keys of the format layout to determine the column names,
+// all other log entries are processed using the regex mapper for the format layout
+type ApiGatewayAccessLogMapper struct {
+	// the regex mapper for non-JSON layouts
+	regexMapper mappers.Mapper[*types.DynamicRow]
+	// for JSON layouts, a map of JSON key to column name
+	jsonFields map[string]string
+}
+
+// NewApiGatewayAccessLogMapper creates a new mapper - exactly one of regexMapper and jsonFields should be set
+func NewApiGatewayAccessLogMapper(regexMapper mappers.Mapper[*types.DynamicRow], jsonFields map[string]string) *ApiGatewayAccessLogMapper {
+	return &ApiGatewayAccessLogMapper{
+		regexMapper: regexMapper,
+		jsonFields:  jsonFields,
+	}
+}
+
+// Identifier returns the mapper identifier
+func (m *ApiGatewayAccessLogMapper) Identifier() string {
+	return "api_gateway_access_log_mapper"
+}
+
+func (m *ApiGatewayAccessLogMapper) Map(ctx context.Context, a any, opts ...mappers.MapOption[*types.DynamicRow]) (*types.DynamicRow, error) {
+	var input string
+
+	// Handle different input types
+	switch v := a.(type) {
+	case []byte:
+		input = string(v)
+	case string:
+		input = v
+	case *string:
+		if v == nil {
+			return nil, fmt.Errorf("nil string input")
+		}
+		input = *v
+	case cwTypes.FilteredLogEvent:
+		input = *v.Message
+	default:
+		return nil, fmt.Errorf("expected byte[], string, or *string, got %T", a)
+	}
+	input = strings.TrimSpace(input)
+
+	if m.regexMapper != nil {
+		return m.regexMapper.Map(ctx, input, opts...)
+	}
+	return m.mapJSON(input)
+}
+
+// mapJSON decodes a JSON log entry - keys in the format layout are mapped to the column name of their $context variable,
+// any other keys are converted to snake case
+func (m *ApiGatewayAccessLogMapper) mapJSON(input string) (*types.DynamicRow, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(input)))
+	decoder.UseNumber()
+
+	var entry map[string]any
+	if err := decoder.Decode(&entry); err != nil {
+		return nil, fmt.Errorf("error decoding JSON: %w", err)
+	}
+
+	rowMap := make(map[string]string, len(entry))
+	for key, value := range entry {
+		columnName, ok := m.jsonFields[key]
+		if !ok {
+			columnName = toSnakeCase(key)
+		}
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			rowMap[columnName] = v
+		case json.Number:
+			rowMap[columnName] = v.String()
+		case map[string]any, []any:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding %s: %w", key, err)
+			}
+			rowMap[columnName] = string(b)
+		default:
+			rowMap[columnName] = fmt.Sprint(v)
+		}
+	}
+
+	row := &types.DynamicRow{}
+	if err := row.InitialiseFromMap(rowMap); err != nil {
+		return nil, fmt.Errorf("error initialising dynamic row: %w", err)
+	}
+	return row, nil
+}
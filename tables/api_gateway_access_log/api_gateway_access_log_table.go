@@ -0,0 +1,299 @@
+package api_gateway_access_log
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/formats"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+const ApiGatewayAccessLogTableIdentifier = "aws_api_gateway_access_log"
+const ApiGatewayAccessLogTableNilValue = "-"
+
+// ApiGatewayAccessLogTable - table for API Gateway access logs
+type ApiGatewayAccessLogTable struct {
+	table.CustomTableImpl
+}
+
+func (c *ApiGatewayAccessLogTable) Identifier() string {
+	return ApiGatewayAccessLogTableIdentifier
+}
+
+func (c *ApiGatewayAccessLogTable) GetDefaultFormat() formats.Format {
+	return defaultApiGatewayAccessLogTableFormat
+}
+
+// GetTableDefinition returns the columns for the most commonly used $context variables - any other variables
+// in the format layout are also collected, with the column name derived from the variable name
+func (c *ApiGatewayAccessLogTable) GetTableDefinition() *schema.TableSchema {
+	return &schema.TableSchema{
+		Name: ApiGatewayAccessLogTableIdentifier,
+		Columns: []*schema.ColumnSchema{
+			{
+				ColumnName:  "api_id",
+				Description: "The identifier API Gateway assigns to your API ($context.apiId).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "authorizer_error",
+				Description: "The error message returned from an authorizer ($context.authorizer.error).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "domain_name",
+				Description: "The full domain name used to invoke the API ($context.domainName).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "domain_prefix",
+				Description: "The first label of the domain name used to invoke the API ($context.domainPrefix).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "error_message",
+				Description: "A string containing an API Gateway error message ($context.error.message).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "error_response_type",
+				Description: "The type of GatewayResponse returned for an error ($context.error.responseType).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "extended_request_id",
+				Description: "The extended ID that API Gateway generates and assigns to the API request ($context.extendedRequestId).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "http_method",
+				Description: "The HTTP method used, e.g. GET or POST ($context.httpMethod).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "identity_account_id",
+				Description: "The AWS account ID associated with the request, for IAM authorized requests ($context.identity.accountId).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "identity_api_key_id",
+				Description: "The API key ID associated with a key-required API request ($context.identity.apiKeyId).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "identity_caller",
+				Description: "The principal identifier of the caller that signed the request ($context.identity.caller).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "identity_source_ip",
+				Description: "The source IP address of the immediate TCP connection making the request to the API Gateway endpoint ($context.identity.sourceIp).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "identity_user",
+				Description: "The principal identifier of the user that will be authorized against resource access ($context.identity.user).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "identity_user_agent",
+				Description: "The User-Agent header of the API caller ($context.identity.userAgent).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "identity_user_arn",
+				Description: "The ARN of the effective user identified after authentication ($context.identity.userArn).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "integration_error",
+				Description: "The error message returned from an integration ($context.integration.error).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "integration_latency",
+				Description: "The integration latency in milliseconds ($context.integration.latency).",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "integration_status",
+				Description: "The status code returned from an integration ($context.integration.status).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "path",
+				Description: "The request path ($context.path).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "protocol",
+				Description: "The request protocol, e.g. HTTP/1.1 ($context.protocol).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "request_id",
+				Description: "The ID that API Gateway assigns to the API request ($context.requestId).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "request_time",
+				Description: "The CLF-formatted request time ($context.requestTime).",
+				Type:        "timestamp",
+			},
+			{
+				ColumnName:  "request_time_epoch",
+				Description: "The Epoch-formatted request time, in milliseconds ($context.requestTimeEpoch).",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "resource_id",
+				Description: "The identifier that API Gateway assigns to your resource ($context.resourceId).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "resource_path",
+				Description: "The path to your resource, e.g. /pets/{petId} ($context.resourcePath).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "response_latency",
+				Description: "The response latency in milliseconds ($context.responseLatency).",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "response_length",
+				Description: "The response payload length in bytes ($context.responseLength).",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "route_key",
+				Description: "The route key of the API request, for HTTP and WebSocket APIs ($context.routeKey).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "stage",
+				Description: "The deployment stage of the API request ($context.stage).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "status",
+				Description: "The method response status ($context.status).",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "waf_response_code",
+				Description: "The response received from AWS WAF, WAF_ALLOW or WAF_BLOCK ($context.wafResponseCode).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "xray_trace_id",
+				Description: "The trace ID for the X-Ray trace ($context.xrayTraceId).",
+				Type:        "varchar",
+			},
+		},
+		NullIf:      ApiGatewayAccessLogTableNilValue,
+		Description: c.GetDescription(),
+	}
+}
+
+func (c *ApiGatewayAccessLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*types.DynamicRow], error) {
+	// API Gateway delivers access logs to S3 via a Firehose stream, whose name must begin with amazon-apigateway-
+	// this is the default Firehose object key format
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/%{HOUR:hour}/amazon-apigateway-%{DATA}"),
+	}
+
+	format, ok := c.Format.(*ApiGatewayAccessLogTableFormat)
+	if !ok {
+		return nil, fmt.Errorf("invalid format type: expected *ApiGatewayAccessLogTableFormat")
+	}
+
+	mapper, err := format.GetMapper()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mapper: %w", err)
+	}
+
+	return []*table.SourceMetadata[*types.DynamicRow]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Mapper:     mapper,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithRowPerLine(),
+			},
+		},
+		{
+			// CloudWatch source
+			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
+			Mapper:     mapper,
+		},
+		{
+			// any artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Mapper:     mapper,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithRowPerLine(),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *ApiGatewayAccessLogTable) EnrichRow(row *types.DynamicRow, sourceEnrichmentFields schema.SourceEnrichment) (*types.DynamicRow, error) {
+	row.OutputColumns[constants.TpTable] = ApiGatewayAccessLogTableIdentifier
+
+	// tp_timestamp - use requestTime if present in the layout, otherwise requestTimeEpoch
+	if requestTime, ok := row.GetSourceValue("request_time"); ok && requestTime != ApiGatewayAccessLogTableNilValue {
+		t, err := helpers.ParseTime(requestTime)
+		if err != nil {
+			return nil, error_types.NewRowErrorWithFields([]string{}, []string{"request_time"})
+		}
+		row.OutputColumns["request_time"] = t
+		row.OutputColumns[constants.TpTimestamp] = t
+	} else if requestTimeEpoch, ok := row.GetSourceValue("request_time_epoch"); ok && requestTimeEpoch != ApiGatewayAccessLogTableNilValue {
+		ms, err := strconv.ParseInt(requestTimeEpoch, 10, 64)
+		if err != nil {
+			return nil, error_types.NewRowErrorWithFields([]string{}, []string{"request_time_epoch"})
+		}
+		row.OutputColumns[constants.TpTimestamp] = time.UnixMilli(ms).UTC()
+	} else {
+		return nil, error_types.NewRowErrorWithFields([]string{"request_time"}, []string{})
+	}
+
+	// tp_source_ip and tp_ips
+	if sourceIp, ok := row.GetSourceValue("identity_source_ip"); ok && sourceIp != ApiGatewayAccessLogTableNilValue {
+		row.OutputColumns[constants.TpSourceIP] = sourceIp
+		row.OutputColumns[constants.TpIps] = []string{sourceIp}
+	}
+
+	// tp_domains
+	if domainName, ok := row.GetSourceValue("domain_name"); ok && domainName != ApiGatewayAccessLogTableNilValue {
+		row.OutputColumns[constants.TpDomains] = []string{domainName}
+	}
+
+	// tp_akas and tp_usernames
+	if userArn, ok := row.GetSourceValue("identity_user_arn"); ok && userArn != ApiGatewayAccessLogTableNilValue {
+		row.OutputColumns[constants.TpAkas] = []string{userArn}
+		row.OutputColumns[constants.TpUsernames] = []string{userArn}
+	}
+
+	// now call the base class to do the rest of the enrichment
+	return c.CustomTableImpl.EnrichRow(row, sourceEnrichmentFields)
+}
+
+func (c *ApiGatewayAccessLogTable) GetDescription() string {
+	return "AWS API Gateway access logs record the requests made to your APIs, in a format defined by the $context variables selected for each stage. This table provides details of each request, including the caller, source IP address, method and resource, response status and latencies, helping teams monitor API usage, troubleshoot errors and detect abuse."
+}
@@ -6,11 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
 type ClbAccessLog struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	BackendIP              *string   `json:"backend_ip,omitempty"`
 	BackendPort            int       `json:"backend_port,omitempty"`
@@ -123,7 +125,7 @@ func (l *ClbAccessLog) InitialiseFromMap(m map[string]string) error {
 }
 
 func (c *ClbAccessLog) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"backend_ip":               "The IP address of the registered instance that processed the request.",
 		"backend_port":             "The port on the registered instance that processed the request.",
 		"backend_processing_time":  "The time elapsed from the load balancer sending the request to the registered instance until the instance starts sending response headers.",
@@ -149,5 +151,5 @@ func (c *ClbAccessLog) GetColumnDescriptions() map[string]string {
 		"tp_source_ip":      "The IP address of the requesting client.",
 		"tp_ips":            "The IP addresses of the requesting client and the registered instance that processed the request.",
 		"tp_destination_ip": "The IP address of the registered instance that processed the request.",
-	}
+	})
 }
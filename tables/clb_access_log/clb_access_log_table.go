@@ -52,6 +52,7 @@ func (c *ClbAccessLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*ClbAcc
 
 func (c *ClbAccessLogTable) EnrichRow(row *ClbAccessLog, sourceEnrichmentFields schema.SourceEnrichment) (*ClbAccessLog, error) {
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	row.TpID = xid.New().String()
 	row.TpIngestTimestamp = time.Now()
@@ -0,0 +1,262 @@
+package cloudfront_access_log
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type CloudFrontAccessLog struct {
+	schema.CommonFields
+
+	Asn                      *int64    `json:"asn,omitempty"`
+	CacheBehaviorPathPattern *string   `json:"cache_behavior_path_pattern,omitempty"`
+	ClientCountry            *string   `json:"client_country,omitempty"`
+	ClientIP                 *string   `json:"client_ip,omitempty"`
+	ClientPort               *int      `json:"client_port,omitempty"`
+	CsBytes                  *int64    `json:"cs_bytes,omitempty"`
+	CsCookie                 *string   `json:"cs_cookie,omitempty"`
+	CsHost                   *string   `json:"cs_host,omitempty"`
+	CsMethod                 *string   `json:"cs_method,omitempty"`
+	CsProtocol               *string   `json:"cs_protocol,omitempty"`
+	CsProtocolVersion        *string   `json:"cs_protocol_version,omitempty"`
+	CsReferer                *string   `json:"cs_referer,omitempty"`
+	CsUriQuery               *string   `json:"cs_uri_query,omitempty"`
+	CsUriStem                *string   `json:"cs_uri_stem,omitempty"`
+	CsUserAgent              *string   `json:"cs_user_agent,omitempty"`
+	DistributionId           *string   `json:"distribution_id,omitempty"`
+	EdgeDetailedResultType   *string   `json:"edge_detailed_result_type,omitempty"`
+	EdgeLocation             *string   `json:"edge_location,omitempty"`
+	EdgeRequestId            *string   `json:"edge_request_id,omitempty"`
+	EdgeResponseResultType   *string   `json:"edge_response_result_type,omitempty"`
+	EdgeResultType           *string   `json:"edge_result_type,omitempty"`
+	FleEncryptedFields       *string   `json:"fle_encrypted_fields,omitempty"`
+	FleStatus                *string   `json:"fle_status,omitempty"`
+	HostHeader               *string   `json:"host_header,omitempty"`
+	OriginFbl                *float64  `json:"origin_fbl,omitempty"`
+	OriginLbl                *float64  `json:"origin_lbl,omitempty"`
+	ScBytes                  *int64    `json:"sc_bytes,omitempty"`
+	ScContentLen             *int64    `json:"sc_content_len,omitempty"`
+	ScContentType            *string   `json:"sc_content_type,omitempty"`
+	ScRangeEnd               *int64    `json:"sc_range_end,omitempty"`
+	ScRangeStart             *int64    `json:"sc_range_start,omitempty"`
+	ScStatus                 *int      `json:"sc_status,omitempty"`
+	SslCipher                *string   `json:"ssl_cipher,omitempty"`
+	SslProtocol              *string   `json:"ssl_protocol,omitempty"`
+	TimeTaken                *float64  `json:"time_taken,omitempty"`
+	TimeToFirstByte          *float64  `json:"time_to_first_byte,omitempty"`
+	Timestamp                time.Time `json:"timestamp"`
+	XForwardedFor            *string   `json:"x_forwarded_for,omitempty"`
+}
+
+// InitialiseFromMap - initialise the struct from a map of CloudFront log field names to values
+func (l *CloudFrontAccessLog) InitialiseFromMap(m map[string]string) error {
+	var date, clock string
+	var timestamp *time.Time
+
+	for key, value := range m {
+		if value == "-" || value == "" {
+			continue
+		}
+		var err error
+		switch key {
+		case "date":
+			date = value
+		case "time":
+			clock = value
+		case "timestamp":
+			// standard logging v2 - unix epoch seconds, with optional milliseconds
+			var ts float64
+			ts, err = strconv.ParseFloat(value, 64)
+			if err == nil {
+				t := time.UnixMilli(int64(math.Round(ts * 1000))).UTC()
+				timestamp = &t
+			}
+		case "timestamp(ms)":
+			// standard logging v2 - unix epoch milliseconds
+			var ms int64
+			ms, err = strconv.ParseInt(value, 10, 64)
+			if err == nil {
+				t := time.UnixMilli(ms).UTC()
+				timestamp = &t
+			}
+		case "DistributionId":
+			l.DistributionId = &value
+		case "x-edge-location":
+			l.EdgeLocation = &value
+		case "sc-bytes":
+			l.ScBytes, err = parseInt64(value)
+		case "c-ip":
+			l.ClientIP = &value
+		case "cs-method":
+			l.CsMethod = &value
+		case "cs(Host)":
+			l.CsHost = &value
+		case "cs-uri-stem":
+			l.CsUriStem = &value
+		case "sc-status":
+			l.ScStatus, err = parseInt(value)
+		case "cs(Referer)":
+			l.CsReferer = &value
+		case "cs(User-Agent)":
+			l.CsUserAgent = urlDecode(value)
+		case "cs-uri-query":
+			l.CsUriQuery = urlDecode(value)
+		case "cs(Cookie)":
+			l.CsCookie = &value
+		case "x-edge-result-type":
+			l.EdgeResultType = &value
+		case "x-edge-request-id":
+			l.EdgeRequestId = &value
+		case "x-host-header":
+			l.HostHeader = &value
+		case "cs-protocol":
+			l.CsProtocol = &value
+		case "cs-bytes":
+			l.CsBytes, err = parseInt64(value)
+		case "time-taken":
+			l.TimeTaken, err = parseFloat(value)
+		case "x-forwarded-for":
+			l.XForwardedFor = &value
+		case "ssl-protocol":
+			l.SslProtocol = &value
+		case "ssl-cipher":
+			l.SslCipher = &value
+		case "x-edge-response-result-type":
+			l.EdgeResponseResultType = &value
+		case "cs-protocol-version":
+			l.CsProtocolVersion = &value
+		case "fle-status":
+			l.FleStatus = &value
+		case "fle-encrypted-fields":
+			l.FleEncryptedFields = &value
+		case "c-port":
+			l.ClientPort, err = parseInt(value)
+		case "time-to-first-byte":
+			l.TimeToFirstByte, err = parseFloat(value)
+		case "x-edge-detailed-result-type":
+			l.EdgeDetailedResultType = &value
+		case "sc-content-type":
+			l.ScContentType = &value
+		case "sc-content-len":
+			l.ScContentLen, err = parseInt64(value)
+		case "sc-range-start":
+			l.ScRangeStart, err = parseInt64(value)
+		case "sc-range-end":
+			l.ScRangeEnd, err = parseInt64(value)
+		case "c-country":
+			l.ClientCountry = &value
+		case "cache-behavior-path-pattern":
+			l.CacheBehaviorPathPattern = &value
+		case "origin-fbl":
+			l.OriginFbl, err = parseFloat(value)
+		case "origin-lbl":
+			l.OriginLbl, err = parseFloat(value)
+		case "asn":
+			l.Asn, err = parseInt64(value)
+		}
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", key, err)
+		}
+	}
+
+	// the legacy format (and v2 unless the timestamp field is selected) records the date and time separately, in UTC
+	if timestamp == nil && date != "" && clock != "" {
+		t, err := time.Parse("2006-01-02 15:04:05", date+" "+clock)
+		if err != nil {
+			return fmt.Errorf("error parsing date and time: %w", err)
+		}
+		timestamp = &t
+	}
+	if timestamp == nil {
+		return fmt.Errorf("no timestamp found in log entry")
+	}
+	l.Timestamp = *timestamp
+
+	return nil
+}
+
+// urlDecode decodes a URL encoded field, returning the original value if it cannot be decoded
+// NOTE: CloudFront encodes spaces as %20 and some values are encoded twice, so '+' is not treated as a space
+func urlDecode(value string) *string {
+	decoded, err := url.PathUnescape(value)
+	if err != nil {
+		return &value
+	}
+	return &decoded
+}
+
+func parseInt(value string) (*int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func parseInt64(value string) (*int64, error) {
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func parseFloat(value string) (*float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (l *CloudFrontAccessLog) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"asn":                         "The autonomous system number (ASN) of the viewer.",
+		"cache_behavior_path_pattern": "The path pattern that identifies the cache behavior that matched the viewer request.",
+		"client_country":              "The country code of the viewer, determined by the viewer's IP address.",
+		"client_ip":                   "The IP address of the viewer that made the request.",
+		"client_port":                 "The port number of the request from the viewer.",
+		"cs_bytes":                    "The total number of bytes of data that the viewer included in the request, including headers.",
+		"cs_cookie":                   "The cookie header in the request, including name-value pairs and the associated attributes.",
+		"cs_host":                     "The domain name of the CloudFront distribution (e.g. d111111abcdef8.cloudfront.net).",
+		"cs_method":                   "The HTTP request method received from the viewer.",
+		"cs_protocol":                 "The protocol of the viewer request (http, https, grpcs, ws or wss).",
+		"cs_protocol_version":         "The HTTP version that the viewer specified in the request.",
+		"cs_referer":                  "The value of the Referer header in the request.",
+		"cs_uri_query":                "The query string portion of the request URL, URL decoded.",
+		"cs_uri_stem":                 "The portion of the request URL that identifies the path and object.",
+		"cs_user_agent":               "The value of the User-Agent header in the request, URL decoded.",
+		"distribution_id":             "The ID of the CloudFront distribution.",
+		"edge_detailed_result_type":   "The detailed result type of the request, e.g. the reason for an error response.",
+		"edge_location":               "The edge location that served the request, identified by a three-letter code and an assigned number.",
+		"edge_request_id":             "An opaque string that uniquely identifies the request.",
+		"edge_response_result_type":   "How the server classified the response just before returning it to the viewer (Hit, RefreshHit, Miss, LimitExceeded, CapacityExceeded, Error or Redirect).",
+		"edge_result_type":            "How the server classified the response after the last byte left the server (Hit, RefreshHit, Miss, LimitExceeded, CapacityExceeded, Error or Redirect).",
+		"fle_encrypted_fields":        "The number of field-level encryption fields that the server encrypted and forwarded to the origin.",
+		"fle_status":                  "The status of field-level encryption for the request, if configured.",
+		"host_header":                 "The value that the viewer included in the Host header of the request, such as an alternate domain name.",
+		"origin_fbl":                  "The number of seconds of first-byte latency between CloudFront and the origin.",
+		"origin_lbl":                  "The number of seconds of last-byte latency between CloudFront and the origin.",
+		"sc_bytes":                    "The total number of bytes that the server sent to the viewer in response to the request, including headers.",
+		"sc_content_len":              "The value of the Content-Length header of the response.",
+		"sc_content_type":             "The value of the Content-Type header of the response.",
+		"sc_range_end":                "When the response contains a Content-Range header, the range end value.",
+		"sc_range_start":              "When the response contains a Content-Range header, the range start value.",
+		"sc_status":                   "The HTTP status code of the response, or 000 if the viewer closed the connection before the server could respond.",
+		"ssl_cipher":                  "The TLS cipher negotiated by the viewer and the server, for HTTPS requests.",
+		"ssl_protocol":                "The TLS protocol negotiated by the viewer and the server, for HTTPS requests.",
+		"time_taken":                  "The number of seconds between the server receiving the request and writing the last byte of the response.",
+		"time_to_first_byte":          "The number of seconds between the server receiving the request and writing the first byte of the response.",
+		"timestamp":                   "The date and time at which the server finished responding to the request, in UTC.",
+		"x_forwarded_for":             "The value of the X-Forwarded-For header, when the viewer used an HTTP proxy or load balancer.",
+
+		// Tailpipe-specific metadata fields
+		"tp_domains": "The domains involved in the request, including the distribution domain name and the Host header.",
+		"tp_ips":     "The IP addresses involved in the request, including the viewer IP address and any forwarded IP addresses.",
+	}
+}
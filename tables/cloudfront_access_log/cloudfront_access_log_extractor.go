@@ -0,0 +1,184 @@
+package cloudfront_access_log
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+const (
+	w3cFieldsDirective = "#Fields:"
+	parquetMagic       = "PAR1"
+)
+
+// legacyFields are the fields of a CloudFront standard (legacy) log file, in order
+// these are used if the log file does not contain a #Fields directive
+var legacyFields = []string{
+	"date", "time", "x-edge-location", "sc-bytes", "c-ip", "cs-method", "cs(Host)", "cs-uri-stem", "sc-status",
+	"cs(Referer)", "cs(User-Agent)", "cs-uri-query", "cs(Cookie)", "x-edge-result-type", "x-edge-request-id",
+	"x-host-header", "cs-protocol", "cs-bytes", "time-taken", "x-forwarded-for", "ssl-protocol", "ssl-cipher",
+	"x-edge-response-result-type", "cs-protocol-version", "fle-status", "fle-encrypted-fields", "c-port",
+	"time-to-first-byte", "x-edge-detailed-result-type", "sc-content-type", "sc-content-len", "sc-range-start",
+	"sc-range-end",
+}
+
+// CloudFrontAccessLogExtractor is an extractor that receives the content of a CloudFront standard log file and
+// extracts a map of field name to value for each log entry. The following formats are supported:
+//   - W3C (tab-separated, with a #Fields directive) - used by legacy standard logging and standard logging v2
+//   - JSON (one object per line) - standard logging v2
+//   - Parquet - standard logging v2
+type CloudFrontAccessLogExtractor struct {
+}
+
+// NewCloudFrontAccessLogExtractor creates a new CloudFrontAccessLogExtractor
+func NewCloudFrontAccessLogExtractor() artifact_source.Extractor {
+	return &CloudFrontAccessLogExtractor{}
+}
+
+func (c *CloudFrontAccessLogExtractor) Identifier() string {
+	return "cloudfront_access_log_extractor"
+}
+
+// Extract determines the format of the artifact data and returns a map[string]string for each log entry
+func (c *CloudFrontAccessLogExtractor) Extract(ctx context.Context, a any) ([]any, error) {
+	data, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	var records []map[string]string
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte(parquetMagic)):
+		records, err = extractParquet(ctx, data)
+	case bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")):
+		records, err = extractJSON(data)
+	default:
+		records, err = extractW3C(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("CloudFrontAccessLogExtractor", "record count", len(records))
+	res := make([]any, len(records))
+	for i, record := range records {
+		res[i] = record
+	}
+	return res, nil
+}
+
+// extractW3C parses tab-separated log entries, using the #Fields directive to determine the field names
+func extractW3C(data []byte) ([]map[string]string, error) {
+	fields := legacyFields
+	var res []map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// allow for long lines, e.g. large cookies or query strings
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if strings.HasPrefix(line, w3cFieldsDirective) {
+				fields = strings.Fields(strings.TrimPrefix(line, w3cFieldsDirective))
+			}
+			// ignore other directives, e.g. #Version
+			continue
+		}
+
+		values := strings.Split(line, "\t")
+		record := make(map[string]string, len(fields))
+		for i, field := range fields {
+			if i < len(values) {
+				record[field] = values[i]
+			}
+		}
+		res = append(res, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading log file: %w", err)
+	}
+	return res, nil
+}
+
+// extractJSON parses newline-delimited JSON log entries
+func extractJSON(data []byte) ([]map[string]string, error) {
+	var res []map[string]string
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	for {
+		var entry map[string]any
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding json: %w", err)
+		}
+
+		record := make(map[string]string, len(entry))
+		for k, v := range entry {
+			if v == nil {
+				continue
+			}
+			record[k] = fmt.Sprint(v)
+		}
+		res = append(res, record)
+	}
+	return res, nil
+}
+
+// extractParquet reads the log entries from a Parquet file, converting each column value to a string
+func extractParquet(ctx context.Context, data []byte) ([]map[string]string, error) {
+	parquetReader, err := file.NewParquetReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error opening parquet file: %w", err)
+	}
+	defer parquetReader.Close()
+
+	fileReader, err := pqarrow.NewFileReader(parquetReader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("error creating parquet reader: %w", err)
+	}
+
+	tbl, err := fileReader.ReadTable(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading parquet file: %w", err)
+	}
+	defer tbl.Release()
+
+	var res []map[string]string
+
+	tableReader := array.NewTableReader(tbl, 0)
+	defer tableReader.Release()
+	for tableReader.Next() {
+		rec := tableReader.Record()
+		for row := 0; row < int(rec.NumRows()); row++ {
+			record := make(map[string]string, int(rec.NumCols()))
+			for col, column := range rec.Columns() {
+				if column.IsNull(row) {
+					continue
+				}
+				record[rec.ColumnName(col)] = column.ValueStr(row)
+			}
+			res = append(res, record)
+		}
+	}
+	return res, nil
+}
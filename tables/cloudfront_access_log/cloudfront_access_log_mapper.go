@@ -0,0 +1,30 @@
+package cloudfront_access_log
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+)
+
+// CloudFrontAccessLogMapper maps the fields extracted from a CloudFront log entry by the
+// CloudFrontAccessLogExtractor to a CloudFrontAccessLog
+type CloudFrontAccessLogMapper struct {
+}
+
+func (m *CloudFrontAccessLogMapper) Identifier() string {
+	return "cloudfront_access_log_mapper"
+}
+
+func (m *CloudFrontAccessLogMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*CloudFrontAccessLog]) (*CloudFrontAccessLog, error) {
+	fields, ok := a.(map[string]string)
+	if !ok {
+		return nil, fmt.Errorf("expected map[string]string, got %T", a)
+	}
+
+	var log CloudFrontAccessLog
+	if err := log.InitialiseFromMap(fields); err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
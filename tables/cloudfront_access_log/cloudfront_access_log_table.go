@@ -0,0 +1,94 @@
+package cloudfront_access_log
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const CloudFrontAccessLogTableIdentifier = "aws_cloudfront_access_log"
+
+type CloudFrontAccessLogTable struct{}
+
+func (c *CloudFrontAccessLogTable) Identifier() string {
+	return CloudFrontAccessLogTableIdentifier
+}
+
+func (c *CloudFrontAccessLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*CloudFrontAccessLog], error) {
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		// capture only the last path segment as the distribution ID, as the layout is also tried without the prefix
+		FileLayout: utils.ToStringPointer(`(%{DATA}/)?(?<distribution_id>[^/.]+)\.%{YEAR:year}-%{MONTHNUM:month}-%{MONTHDAY:day}-%{HOUR:hour}.%{DATA}.(?:gz|parquet)`),
+	}
+
+	return []*table.SourceMetadata[*CloudFrontAccessLog]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Mapper:     &CloudFrontAccessLogMapper{},
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewCloudFrontAccessLogExtractor()),
+			},
+		},
+		{
+			// any artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Mapper:     &CloudFrontAccessLogMapper{},
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewCloudFrontAccessLogExtractor()),
+			},
+		},
+	}, nil
+}
+
+func (c *CloudFrontAccessLogTable) EnrichRow(row *CloudFrontAccessLog, sourceEnrichmentFields schema.SourceEnrichment) (*CloudFrontAccessLog, error) {
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpIngestTimestamp = time.Now()
+	row.TpTimestamp = row.Timestamp
+	row.TpDate = row.Timestamp.Truncate(24 * time.Hour)
+
+	// the distribution ID is only included in the record by standard logging (v2), if selected as a field,
+	// so fall back to the distribution ID captured from the file name
+	if distributionId, ok := sourceEnrichmentFields.Metadata["distribution_id"]; ok && distributionId != "" && row.DistributionId == nil {
+		row.DistributionId = &distributionId
+	}
+
+	if row.ClientIP != nil {
+		row.TpSourceIP = row.ClientIP
+		row.TpIps = append(row.TpIps, *row.ClientIP)
+	}
+	// X-Forwarded-For may contain a comma separated list of addresses
+	if row.XForwardedFor != nil {
+		for _, ip := range strings.Split(*row.XForwardedFor, ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				row.TpIps = append(row.TpIps, ip)
+			}
+		}
+	}
+
+	if row.CsHost != nil {
+		row.TpDomains = append(row.TpDomains, *row.CsHost)
+	}
+	if row.HostHeader != nil && (row.CsHost == nil || *row.HostHeader != *row.CsHost) {
+		row.TpDomains = append(row.TpDomains, *row.HostHeader)
+	}
+
+	return row, nil
+}
+
+func (c *CloudFrontAccessLogTable) GetDescription() string {
+	return "AWS CloudFront access logs capture detailed information about every user request that CloudFront receives. This table provides a structured representation of standard log data, including viewer details, edge locations, cache results, request and response details, and TLS parameters."
+}
@@ -0,0 +1,77 @@
+package cloudtrail_digest
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+// CloudTrailDigest is a CloudTrail digest file, which records the hashes of the log files delivered in the
+// previous hour, and the hash and signature of the previous digest file
+type CloudTrailDigest struct {
+	schema.CommonFields
+	tables.SourceFields
+
+	AwsAccountId                *string    `json:"awsAccountId,omitempty" parquet:"name=aws_account_id"`
+	DigestEndTime               *time.Time `json:"digestEndTime,omitempty" parquet:"name=digest_end_time"`
+	DigestPublicKeyFingerprint  *string    `json:"digestPublicKeyFingerprint,omitempty" parquet:"name=digest_public_key_fingerprint"`
+	DigestS3Bucket              *string    `json:"digestS3Bucket,omitempty" parquet:"name=digest_s3_bucket"`
+	DigestS3Object              *string    `json:"digestS3Object,omitempty" parquet:"name=digest_s3_object"`
+	DigestSignatureAlgorithm    *string    `json:"digestSignatureAlgorithm,omitempty" parquet:"name=digest_signature_algorithm"`
+	DigestStartTime             *time.Time `json:"digestStartTime,omitempty" parquet:"name=digest_start_time"`
+	LogFileCount                *int       `json:"logFileCount,omitempty" parquet:"name=log_file_count"`
+	LogFiles                    []LogFile  `json:"logFiles,omitempty" parquet:"name=log_files"`
+	NewestEventTime             *time.Time `json:"newestEventTime,omitempty" parquet:"name=newest_event_time"`
+	OldestEventTime             *time.Time `json:"oldestEventTime,omitempty" parquet:"name=oldest_event_time"`
+	PreviousDigestHashAlgorithm *string    `json:"previousDigestHashAlgorithm,omitempty" parquet:"name=previous_digest_hash_algorithm"`
+	PreviousDigestHashValue     *string    `json:"previousDigestHashValue,omitempty" parquet:"name=previous_digest_hash_value"`
+	PreviousDigestS3Bucket      *string    `json:"previousDigestS3Bucket,omitempty" parquet:"name=previous_digest_s3_bucket"`
+	PreviousDigestS3Object      *string    `json:"previousDigestS3Object,omitempty" parquet:"name=previous_digest_s3_object"`
+	PreviousDigestSignature     *string    `json:"previousDigestSignature,omitempty" parquet:"name=previous_digest_signature"`
+
+	// the results of verification by the aws_s3_bucket source, if configured
+	LogFilesVerified            *bool    `json:"logFilesVerified,omitempty" parquet:"name=log_files_verified"`
+	LogFileVerificationErrors   []string `json:"logFileVerificationErrors,omitempty" parquet:"name=log_file_verification_errors"`
+	SignatureVerified           *bool    `json:"signatureVerified,omitempty" parquet:"name=signature_verified"`
+	SignatureVerificationErrors []string `json:"signatureVerificationErrors,omitempty" parquet:"name=signature_verification_errors"`
+}
+
+// LogFile is a log file referenced by a CloudTrail digest file
+type LogFile struct {
+	HashAlgorithm   *string    `json:"hashAlgorithm,omitempty"`
+	HashValue       *string    `json:"hashValue,omitempty"`
+	NewestEventTime *time.Time `json:"newestEventTime,omitempty"`
+	OldestEventTime *time.Time `json:"oldestEventTime,omitempty"`
+	S3Bucket        *string    `json:"s3Bucket,omitempty"`
+	S3Object        *string    `json:"s3Object,omitempty"`
+}
+
+func (c *CloudTrailDigest) GetColumnDescriptions() map[string]string {
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
+		"aws_account_id":                 "The AWS account ID of the trail that the digest file was delivered for.",
+		"digest_end_time":                "The end of the UTC time range covered by the digest file.",
+		"digest_public_key_fingerprint":  "The hexadecimal fingerprint of the public key that matches the private key used to sign the digest file.",
+		"digest_s3_bucket":               "The name of the S3 bucket that the digest file was delivered to.",
+		"digest_s3_object":               "The S3 object key of the digest file.",
+		"digest_signature_algorithm":     "The algorithm used to sign the digest file, e.g. SHA256withRSA.",
+		"digest_start_time":              "The start of the UTC time range covered by the digest file.",
+		"log_file_count":                 "The number of log files referenced by the digest file.",
+		"log_files":                      "The log files delivered in the time range of the digest, with the S3 location, SHA-256 hash and the oldest and newest event times of each.",
+		"log_files_verified":             "Whether the log files and previous digest file referenced by the digest exist and match their hashes. Only set if the aws_s3_bucket source has verify_cloudtrail_digest_log_files enabled.",
+		"log_file_verification_errors":   "The log files and previous digest file which failed verification, because they have been modified or deleted.",
+		"newest_event_time":              "The time of the most recent event in the log files referenced by the digest file.",
+		"oldest_event_time":              "The time of the oldest event in the log files referenced by the digest file.",
+		"previous_digest_hash_algorithm": "The algorithm used to hash the previous digest file.",
+		"previous_digest_hash_value":     "The hexadecimal SHA-256 hash of the uncompressed content of the previous digest file.",
+		"previous_digest_s3_bucket":      "The name of the S3 bucket that the previous digest file was delivered to.",
+		"previous_digest_s3_object":      "The S3 object key of the previous digest file.",
+		"previous_digest_signature":      "The hexadecimal signature of the previous digest file.",
+		"signature_verified":             "Whether the signature of the digest file is valid. Only set if the aws_s3_bucket source has cloudtrail_digest_public_keys configured.",
+		"signature_verification_errors":  "The reasons that the signature of the digest file could not be verified.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARN of the S3 bucket and the account ID of the trail.",
+		"tp_timestamp": "The end of the time range covered by the digest file.",
+	})
+}
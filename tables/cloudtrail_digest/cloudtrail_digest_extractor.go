@@ -0,0 +1,42 @@
+package cloudtrail_digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+// CloudTrailDigestExtractor is an extractor that receives a JSON serialised CloudTrail digest file
+// and extracts a CloudTrailDigest record from it
+type CloudTrailDigestExtractor struct {
+}
+
+// NewCloudTrailDigestExtractor creates a new CloudTrailDigestExtractor
+func NewCloudTrailDigestExtractor() artifact_source.Extractor {
+	return &CloudTrailDigestExtractor{}
+}
+
+func (c *CloudTrailDigestExtractor) Identifier() string {
+	return "cloudtrail_digest_extractor"
+}
+
+// Extract unmarshalls the artifact data as a CloudTrailDigest and returns it as the single record of the artifact
+func (c *CloudTrailDigestExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	// the expected input type is a JSON byte[] deserializable to CloudTrailDigest
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	var digest CloudTrailDigest
+	if err := json.Unmarshal(jsonBytes, &digest); err != nil {
+		return nil, fmt.Errorf("error decoding json: %w", err)
+	}
+
+	logFileCount := len(digest.LogFiles)
+	digest.LogFileCount = &logFileCount
+
+	return []any{&digest}, nil
+}
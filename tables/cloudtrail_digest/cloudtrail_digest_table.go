@@ -0,0 +1,113 @@
+package cloudtrail_digest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const CloudTrailDigestTableIdentifier = "aws_cloudtrail_digest"
+
+// CloudTrailDigestTable - table for CloudTrail digest files
+type CloudTrailDigestTable struct{}
+
+func (t *CloudTrailDigestTable) Identifier() string {
+	return CloudTrailDigestTableIdentifier
+}
+
+func (t *CloudTrailDigestTable) GetSourceMetadata() ([]*table.SourceMetadata[*CloudTrailDigest], error) {
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("AWSLogs/(%{DATA:org_id}/)?%{NUMBER:account_id}/CloudTrail-Digest/%{DATA:region}/%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/%{DATA}.json.gz"),
+	}
+
+	return []*table.SourceMetadata[*CloudTrailDigest]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewCloudTrailDigestExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewCloudTrailDigestExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (t *CloudTrailDigestTable) EnrichRow(row *CloudTrailDigest, sourceEnrichmentFields schema.SourceEnrichment) (*CloudTrailDigest, error) {
+	if row.DigestEndTime == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"digestEndTime"}, nil)
+	}
+
+	// initialize the enrichment fields to any fields provided by the source
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+
+	// the results of digest verification, if the aws_s3_bucket source was configured to verify digests
+	var err error
+	row.SignatureVerified, row.SignatureVerificationErrors, err = verificationFromMetadata(sourceEnrichmentFields.Metadata, s3_bucket.MetadataDigestSignatureVerified, s3_bucket.MetadataDigestSignatureErrors)
+	if err != nil {
+		return nil, err
+	}
+	row.LogFilesVerified, row.LogFileVerificationErrors, err = verificationFromMetadata(sourceEnrichmentFields.Metadata, s3_bucket.MetadataDigestLogFilesVerified, s3_bucket.MetadataDigestLogFileErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *row.DigestEndTime
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = row.DigestEndTime.Truncate(24 * time.Hour)
+
+	if row.DigestS3Bucket != nil {
+		row.TpAkas = append(row.TpAkas, fmt.Sprintf("arn:aws:s3:::%s", *row.DigestS3Bucket))
+	}
+	if row.AwsAccountId != nil {
+		row.TpAkas = append(row.TpAkas, *row.AwsAccountId)
+	}
+
+	return row, nil
+}
+
+func verificationFromMetadata(metadata map[string]string, verifiedKey, errorsKey string) (*bool, []string, error) {
+	verifiedValue, ok := metadata[verifiedKey]
+	if !ok {
+		return nil, nil, nil
+	}
+	verified, err := strconv.ParseBool(verifiedValue)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s: %w", verifiedKey, err)
+	}
+
+	var verificationErrors []string
+	if errorsValue, ok := metadata[errorsKey]; ok {
+		if err := json.Unmarshal([]byte(errorsValue), &verificationErrors); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", errorsKey, err)
+		}
+	}
+	return &verified, verificationErrors, nil
+}
+
+func (t *CloudTrailDigestTable) GetDescription() string {
+	return "AWS CloudTrail digest files record the SHA-256 hashes of the log files delivered by a trail each hour, and are chained together by the hash and signature of the previous digest. This table provides the contents of each digest and, if configured, the results of verifying the digest signature and the referenced log files, allowing the integrity of CloudTrail logs to be demonstrated."
+}
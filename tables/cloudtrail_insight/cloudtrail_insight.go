@@ -0,0 +1,87 @@
+package cloudtrail_insight
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type CloudTrailInsightBatch struct {
+	Records []CloudTrailInsight `json:"Records"`
+}
+
+// CloudTrailInsight is a CloudTrail Insights event, which is logged in pairs marking the start and end of
+// a period of unusual API call rate or API error rate activity
+type CloudTrailInsight struct {
+	// embed required enrichment fields
+	schema.CommonFields
+	tables.SourceFields
+
+	// json tags for marshalling to/from the source & parquet tags handle the parquet column names for the table
+	AwsRegion          string          `json:"awsRegion" parquet:"name=aws_region"`
+	EventCategory      string          `json:"eventCategory,omitempty" parquet:"name=event_category"`
+	EventID            string          `json:"eventID" parquet:"name=event_id"`
+	EventTime          *time.Time      `json:"eventTime" parquet:"name=event_time"`
+	EventType          string          `json:"eventType" parquet:"name=event_type"`
+	EventVersion       string          `json:"eventVersion" parquet:"name=event_version"`
+	InsightDetails     *InsightDetails `json:"insightDetails,omitempty" parquet:"name=insight_details"`
+	RecipientAccountId string          `json:"recipientAccountId,omitempty" parquet:"name=recipient_account_id"`
+	SharedEventID      *string         `json:"sharedEventID,omitempty" parquet:"name=shared_event_id"`
+}
+
+type InsightDetails struct {
+	ErrorCode      *string         `json:"errorCode,omitempty" parquet:"name=error_code"`
+	EventName      *string         `json:"eventName,omitempty" parquet:"name=event_name"`
+	EventSource    *string         `json:"eventSource,omitempty" parquet:"name=event_source"`
+	InsightContext *InsightContext `json:"insightContext,omitempty" parquet:"name=insight_context"`
+	InsightType    *string         `json:"insightType,omitempty" parquet:"name=insight_type"`
+	State          *string         `json:"state,omitempty" parquet:"name=state"`
+}
+
+type InsightContext struct {
+	Attributions []*InsightAttribution `json:"attributions,omitempty" parquet:"name=attributions, type=JSON"`
+	Statistics   *InsightStatistics    `json:"statistics,omitempty" parquet:"name=statistics"`
+}
+
+// InsightAttribution lists the top contributors (user identity ARNs, user agents or error codes) to the
+// activity of the insight, and of the baseline it is compared against
+type InsightAttribution struct {
+	Attribute *string                    `json:"attribute,omitempty"`
+	Baseline  []*InsightAttributionValue `json:"baseline,omitempty"`
+	Insight   []*InsightAttributionValue `json:"insight,omitempty"`
+}
+
+type InsightAttributionValue struct {
+	Average *float64 `json:"average,omitempty"`
+	Value   *string  `json:"value,omitempty"`
+}
+
+type InsightStatistics struct {
+	Baseline         *InsightAverage `json:"baseline,omitempty" parquet:"name=baseline"`
+	BaselineDuration *float64        `json:"baselineDuration,omitempty" parquet:"name=baseline_duration"`
+	Insight          *InsightAverage `json:"insight,omitempty" parquet:"name=insight"`
+	InsightDuration  *float64        `json:"insightDuration,omitempty" parquet:"name=insight_duration"`
+}
+
+type InsightAverage struct {
+	Average *float64 `json:"average,omitempty" parquet:"name=average"`
+}
+
+func (c *CloudTrailInsight) GetColumnDescriptions() map[string]string {
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
+		"aws_region":           "The AWS region in which the unusual activity occurred.",
+		"event_category":       "The category of the event, which is always 'Insight'.",
+		"event_id":             "A unique identifier for the Insights event.",
+		"event_time":           "The time at which the Insights event started or ended, in UTC.",
+		"event_type":           "The type of the event, which is always 'AwsCloudTrailInsight'.",
+		"event_version":        "The version of the CloudTrail Insights event format.",
+		"insight_details":      "Details of the unusual activity, including the state ('Start' or 'End'), the API call it relates to, the insight type ('ApiCallRateInsight' or 'ApiErrorRateInsight'), the insight and baseline statistics, and the attributions of the activity.",
+		"recipient_account_id": "The AWS account ID that received the Insights event.",
+		"shared_event_id":      "A GUID shared by the start and end Insights events of the same unusual activity, allowing them to be correlated.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The user identity ARNs attributed to the unusual activity.",
+		"tp_timestamp": "The time at which the Insights event started or ended.",
+	})
+}
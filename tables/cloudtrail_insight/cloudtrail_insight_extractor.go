@@ -0,0 +1,46 @@
+package cloudtrail_insight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+// CloudTrailInsightExtractor is an extractor that receives JSON serialised CloudTrailInsightBatch objects
+// and extracts CloudTrailInsight records from them
+type CloudTrailInsightExtractor struct {
+}
+
+// NewCloudTrailInsightExtractor creates a new CloudTrailInsightExtractor
+func NewCloudTrailInsightExtractor() artifact_source.Extractor {
+	return &CloudTrailInsightExtractor{}
+}
+
+func (c *CloudTrailInsightExtractor) Identifier() string {
+	return "cloudtrail_insight_extractor"
+}
+
+// Extract unmarshalls the artifact data as a CloudTrailInsightBatch and returns the CloudTrailInsight records
+func (c *CloudTrailInsightExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	// the expected input type is a JSON byte[] deserializable to CloudTrailInsightBatch
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	var batch CloudTrailInsightBatch
+	err := json.Unmarshal(jsonBytes, &batch)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding json: %w", err)
+	}
+
+	slog.Debug("CloudTrailInsightExtractor", "record count", len(batch.Records))
+	var res = make([]any, len(batch.Records))
+	for i, record := range batch.Records {
+		res[i] = &record
+	}
+	return res, nil
+}
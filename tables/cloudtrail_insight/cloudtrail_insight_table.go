@@ -0,0 +1,93 @@
+package cloudtrail_insight
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const CloudTrailInsightTableIdentifier = "aws_cloudtrail_insight"
+
+// CloudTrailInsightTable - table for CloudTrail Insights events
+type CloudTrailInsightTable struct{}
+
+func (t *CloudTrailInsightTable) Identifier() string {
+	return CloudTrailInsightTableIdentifier
+}
+
+func (t *CloudTrailInsightTable) GetSourceMetadata() ([]*table.SourceMetadata[*CloudTrailInsight], error) {
+	// Insights events are delivered under a CloudTrail-Insight prefix alongside the CloudTrail prefix of the trail
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("AWSLogs/(%{DATA:org_id}/)?%{NUMBER:account_id}/CloudTrail-Insight/%{DATA:region}/%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/%{DATA}.json.gz"),
+	}
+
+	return []*table.SourceMetadata[*CloudTrailInsight]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewCloudTrailInsightExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewCloudTrailInsightExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (t *CloudTrailInsightTable) EnrichRow(row *CloudTrailInsight, sourceEnrichmentFields schema.SourceEnrichment) (*CloudTrailInsight, error) {
+	if row.EventTime == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"eventTime"}, nil)
+	}
+
+	// initialize the enrichment fields to any fields provided by the source
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *row.EventTime
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = row.EventTime.Truncate(24 * time.Hour)
+
+	// the user identities which contributed to the unusual activity
+	if row.InsightDetails != nil && row.InsightDetails.InsightContext != nil {
+		for _, attribution := range row.InsightDetails.InsightContext.Attributions {
+			if attribution == nil || attribution.Attribute == nil || *attribution.Attribute != "userIdentityArn" {
+				continue
+			}
+			for _, value := range attribution.Insight {
+				if value == nil || value.Value == nil || !strings.HasPrefix(*value.Value, "arn:") {
+					continue
+				}
+				if !slices.Contains(row.TpAkas, *value.Value) {
+					row.TpAkas = append(row.TpAkas, *value.Value)
+				}
+			}
+		}
+	}
+
+	return row, nil
+}
+
+func (t *CloudTrailInsightTable) GetDescription() string {
+	return "AWS CloudTrail Insights events record the start and end of unusual write API call rate or error rate activity in an account, compared with its baseline, along with the user identities, user agents and error codes which contributed to it."
+}
@@ -0,0 +1,57 @@
+package cloudtrail_log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudtrail_lake"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+// CloudTrailLakeQueryResultsExtractor is an extractor that receives either the CSV query results CloudTrail Lake
+// delivers to S3, or JSON serialised CloudTrailLogBatch objects, and extracts CloudTrailLog records from them
+type CloudTrailLakeQueryResultsExtractor struct {
+	logExtractor artifact_source.Extractor
+}
+
+// NewCloudTrailLakeQueryResultsExtractor creates a new CloudTrailLakeQueryResultsExtractor
+func NewCloudTrailLakeQueryResultsExtractor() artifact_source.Extractor {
+	return &CloudTrailLakeQueryResultsExtractor{
+		logExtractor: NewCloudTrailLogExtractor(),
+	}
+}
+
+func (c *CloudTrailLakeQueryResultsExtractor) Identifier() string {
+	return "cloudtrail_lake_query_results_extractor"
+}
+
+// Extract returns the CloudTrailLog records from CloudTrail Lake query results.
+// If the artifact data does not start with a query results header row, it is extracted as a CloudTrailLogBatch.
+func (c *CloudTrailLakeQueryResultsExtractor) Extract(ctx context.Context, a any) ([]any, error) {
+	data, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	if !cloudtrail_lake.IsQueryResultsCSV(data) {
+		return c.logExtractor.Extract(ctx, data)
+	}
+
+	events, err := cloudtrail_lake.EventsFromQueryResultsCSV(data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CloudTrail Lake query results: %w", err)
+	}
+
+	slog.Debug("CloudTrailLakeQueryResultsExtractor", "query result count", len(events))
+	var res = make([]any, len(events))
+	for i, event := range events {
+		var record CloudTrailLog
+		if err := json.Unmarshal(event, &record); err != nil {
+			return nil, fmt.Errorf("error decoding json: %w", err)
+		}
+		res[i] = &record
+	}
+	return res, nil
+}
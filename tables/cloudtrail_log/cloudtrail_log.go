@@ -3,6 +3,7 @@ package cloudtrail_log
 import (
 	"time"
 
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 	"github.com/turbot/tailpipe-plugin-sdk/types"
 )
@@ -14,6 +15,7 @@ type CloudTrailLogBatch struct {
 type CloudTrailLog struct {
 	// embed required enrichment fields
 	schema.CommonFields
+	tables.SourceFields
 
 	// json tags for marshalling to/from the source & parquet tags handle the parquet column names for the table
 	AdditionalEventData          *map[string]interface{} `json:"additionalEventData,omitempty" parquet:"name=additional_event_data, type=JSON"`
@@ -96,7 +98,7 @@ type TLSDetails struct {
 }
 
 func (c *CloudTrailLog) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"additional_event_data":           "Additional information about the event that is specific to the service being called.",
 		"api_version":                     "The API version associated with the event.",
 		"aws_region":                      "The AWS region where the event originated.",
@@ -132,5 +134,5 @@ func (c *CloudTrailLog) GetColumnDescriptions() map[string]string {
 		"tp_ips":       "IP addresses associated with the event, including the source IP address.",
 		"tp_timestamp": "The date and time the event occurred, in ISO 8601 format.",
 		"tp_usernames": "Usernames or access key IDs associated with the event.",
-	}
+	})
 }
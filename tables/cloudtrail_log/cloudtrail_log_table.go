@@ -7,6 +7,8 @@ import (
 	"github.com/rs/xid"
 
 	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudtrail_lake"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudtrail_lookup_events"
 	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
 	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
 	"github.com/turbot/tailpipe-plugin-aws/tables"
@@ -30,11 +32,11 @@ func (t *CloudTrailLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*Cloud
 
 	return []*table.SourceMetadata[*CloudTrailLog]{
 		{
-			// S3 artifact source
+			// S3 artifact source - CloudTrail Lake query results may also be delivered to S3
 			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
 			Options: []row_source.RowSourceOption{
 				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
-				artifact_source.WithArtifactExtractor(NewCloudTrailLogExtractor()),
+				artifact_source.WithArtifactExtractor(NewCloudTrailLakeQueryResultsExtractor()),
 			},
 		},
 		{
@@ -48,6 +50,14 @@ func (t *CloudTrailLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*Cloud
 			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
 			Mapper:     &CloudTrailMapper{},
 		},
+		{
+			SourceName: cloudtrail_lake.AwsCloudTrailLakeSourceIdentifier,
+			Mapper:     &CloudTrailMapper{},
+		},
+		{
+			SourceName: cloudtrail_lookup_events.AwsCloudTrailLookupEventsSourceIdentifier,
+			Mapper:     &CloudTrailMapper{},
+		},
 	}, nil
 }
 
@@ -60,6 +70,7 @@ func (t *CloudTrailLogTable) Identifier() string {
 func (t *CloudTrailLogTable) EnrichRow(row *CloudTrailLog, sourceEnrichmentFields schema.SourceEnrichment) (*CloudTrailLog, error) {
 	// initialize the enrichment fields to any fields provided by the source
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	// Record standardization
 	row.TpID = xid.New().String()
@@ -0,0 +1,118 @@
+package config_configuration_item
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+// ConfigConfigurationItemBatch is the content of a configuration history or configuration snapshot file
+type ConfigConfigurationItemBatch struct {
+	ConfigSnapshotId   *string                    `json:"configSnapshotId,omitempty"`
+	ConfigurationItems []*ConfigConfigurationItem `json:"configurationItems"`
+	FileVersion        *string                    `json:"fileVersion,omitempty"`
+}
+
+type ConfigConfigurationItem struct {
+	// embed required enrichment fields
+	schema.CommonFields
+	tables.SourceFields
+
+	// json tags for marshalling to/from the source & parquet tags handle the parquet column names for the table
+	ARN                          *string                 `json:"ARN,omitempty" parquet:"name=arn"`
+	AvailabilityZone             *string                 `json:"availabilityZone,omitempty" parquet:"name=availability_zone"`
+	AwsAccountId                 *string                 `json:"awsAccountId,omitempty" parquet:"name=aws_account_id"`
+	AwsRegion                    *string                 `json:"awsRegion,omitempty" parquet:"name=aws_region"`
+	ConfigSnapshotId             *string                 `json:"configSnapshotId,omitempty" parquet:"name=config_snapshot_id"`
+	Configuration                interface{}             `json:"configuration,omitempty" parquet:"name=configuration, type=JSON"`
+	ConfigurationItemCaptureTime *time.Time              `json:"configurationItemCaptureTime,omitempty" parquet:"name=configuration_item_capture_time"`
+	ConfigurationItemStatus      *string                 `json:"configurationItemStatus,omitempty" parquet:"name=configuration_item_status"`
+	ConfigurationItemVersion     *string                 `json:"configurationItemVersion,omitempty" parquet:"name=configuration_item_version"`
+	ConfigurationStateId         *string                 `json:"configurationStateId,omitempty" parquet:"name=configuration_state_id"`
+	ConfigurationStateMd5Hash    *string                 `json:"configurationStateMd5Hash,omitempty" parquet:"name=configuration_state_md5_hash"`
+	RelatedEvents                []string                `json:"relatedEvents,omitempty" parquet:"name=related_events"`
+	Relationships                []Relationship          `json:"relationships,omitempty" parquet:"name=relationships, type=JSON"`
+	ResourceCreationTime         *time.Time              `json:"resourceCreationTime,omitempty" parquet:"name=resource_creation_time"`
+	ResourceId                   *string                 `json:"resourceId,omitempty" parquet:"name=resource_id"`
+	ResourceName                 *string                 `json:"resourceName,omitempty" parquet:"name=resource_name"`
+	ResourceType                 *string                 `json:"resourceType,omitempty" parquet:"name=resource_type"`
+	SupplementaryConfiguration   *map[string]interface{} `json:"supplementaryConfiguration,omitempty" parquet:"name=supplementary_configuration, type=JSON"`
+	Tags                         *map[string]string      `json:"tags,omitempty" parquet:"name=tags, type=JSON"`
+}
+
+// UnmarshalJSON decodes a configuration item, accepting the configuration state ID as either a number
+// (as written to configuration history and snapshot files) or a string (as returned by the API)
+func (c *ConfigConfigurationItem) UnmarshalJSON(data []byte) error {
+	type alias ConfigConfigurationItem
+	temp := struct {
+		*alias
+		ConfigurationStateId *json.Number `json:"configurationStateId,omitempty"`
+	}{
+		alias: (*alias)(c),
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	if temp.ConfigurationStateId != nil {
+		id := temp.ConfigurationStateId.String()
+		c.ConfigurationStateId = &id
+	}
+	return nil
+}
+
+// Relationship is a relationship between the resource and another resource
+type Relationship struct {
+	Name         *string `json:"name,omitempty"`
+	ResourceId   *string `json:"resourceId,omitempty"`
+	ResourceName *string `json:"resourceName,omitempty"`
+	ResourceType *string `json:"resourceType,omitempty"`
+}
+
+// UnmarshalJSON decodes a relationship - configuration history files use "name" for the relationship name,
+// whereas configuration snapshot files use "relationshipName"
+func (r *Relationship) UnmarshalJSON(data []byte) error {
+	type alias Relationship
+	temp := struct {
+		*alias
+		RelationshipName *string `json:"relationshipName,omitempty"`
+	}{
+		alias: (*alias)(r),
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	if r.Name == nil {
+		r.Name = temp.RelationshipName
+	}
+	return nil
+}
+
+func (c *ConfigConfigurationItem) GetColumnDescriptions() map[string]string {
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
+		"arn":                             "The Amazon Resource Name (ARN) of the resource.",
+		"availability_zone":               "The Availability Zone associated with the resource.",
+		"aws_account_id":                  "The ID of the AWS account that owns the resource.",
+		"aws_region":                      "The AWS region where the resource resides.",
+		"config_snapshot_id":              "The ID of the configuration snapshot that contained the configuration item, for items delivered in a configuration snapshot.",
+		"configuration":                   "The description of the resource configuration, as a JSON document.",
+		"configuration_item_capture_time": "The time when AWS Config recorded the configuration item.",
+		"configuration_item_status":       "The status of the configuration item, e.g. OK, ResourceDiscovered, ResourceNotRecorded, ResourceDeleted or ResourceDeletedNotRecorded.",
+		"configuration_item_version":      "The version number of the configuration item.",
+		"configuration_state_id":          "An identifier that indicates the ordering of the configuration items of a resource.",
+		"configuration_state_md5_hash":    "The MD5 hash of the configuration item. This field is deprecated and may be empty.",
+		"related_events":                  "The IDs of the CloudTrail events that are related to the change in the resource configuration.",
+		"relationships":                   "The relationships between the resource and other resources, each with the relationship name and the related resource ID, name and type.",
+		"resource_creation_time":          "The time stamp when the resource was created.",
+		"resource_id":                     "The ID of the resource, e.g. sg-xxxxxx.",
+		"resource_name":                   "The custom name of the resource, if available.",
+		"resource_type":                   "The type of the resource, e.g. AWS::EC2::Instance.",
+		"supplementary_configuration":     "The configuration attributes that AWS Config returns for certain resource types to supplement the information returned for the configuration parameter, as a JSON document.",
+		"tags":                            "The tags associated with the resource.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARN of the resource and the identifiers derived from it, such as the account ID.",
+		"tp_timestamp": "The time when AWS Config recorded the configuration item.",
+	})
+}
@@ -0,0 +1,51 @@
+package config_configuration_item
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+// ConfigConfigurationItemExtractor is an extractor that receives JSON serialised ConfigConfigurationItemBatch objects
+// (configuration history and configuration snapshot files) and extracts ConfigConfigurationItem records from them
+type ConfigConfigurationItemExtractor struct {
+}
+
+// NewConfigConfigurationItemExtractor creates a new ConfigConfigurationItemExtractor
+func NewConfigConfigurationItemExtractor() artifact_source.Extractor {
+	return &ConfigConfigurationItemExtractor{}
+}
+
+func (c *ConfigConfigurationItemExtractor) Identifier() string {
+	return "config_configuration_item_extractor"
+}
+
+// Extract unmarshalls the artifact data as a ConfigConfigurationItemBatch and returns the ConfigConfigurationItem records
+func (c *ConfigConfigurationItemExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	// the expected input type is a JSON byte[] deserializable to ConfigConfigurationItemBatch
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	var batch ConfigConfigurationItemBatch
+	err := json.Unmarshal(jsonBytes, &batch)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding json: %w", err)
+	}
+
+	slog.Debug("ConfigConfigurationItemExtractor", "record count", len(batch.ConfigurationItems))
+	var res = make([]any, 0, len(batch.ConfigurationItems))
+	for _, item := range batch.ConfigurationItems {
+		if item == nil {
+			continue
+		}
+		// items in a snapshot file share the snapshot ID, which is only recorded at the file level
+		item.ConfigSnapshotId = batch.ConfigSnapshotId
+		res = append(res, item)
+	}
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package config_configuration_item
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const ConfigConfigurationItemTableIdentifier = "aws_config_configuration_item"
+
+// ConfigConfigurationItemTable - table for AWS Config configuration items
+type ConfigConfigurationItemTable struct{}
+
+func (t *ConfigConfigurationItemTable) Identifier() string {
+	return ConfigConfigurationItemTableIdentifier
+}
+
+func (t *ConfigConfigurationItemTable) GetSourceMetadata() ([]*table.SourceMetadata[*ConfigConfigurationItem], error) {
+	// the default file layout for configuration history and snapshot files in S3
+	// (the month and day are not zero padded)
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("AWSLogs/(%{DATA:org_id}/)?%{NUMBER:account_id}/Config/%{DATA:region}/%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/(ConfigHistory|ConfigSnapshot)/%{DATA}.json.gz"),
+	}
+
+	return []*table.SourceMetadata[*ConfigConfigurationItem]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewConfigConfigurationItemExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewConfigConfigurationItemExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (t *ConfigConfigurationItemTable) EnrichRow(row *ConfigConfigurationItem, sourceEnrichmentFields schema.SourceEnrichment) (*ConfigConfigurationItem, error) {
+	if row.ConfigurationItemCaptureTime == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"configurationItemCaptureTime"}, nil)
+	}
+
+	// initialize the enrichment fields to any fields provided by the source
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *row.ConfigurationItemCaptureTime
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = row.ConfigurationItemCaptureTime.Truncate(24 * time.Hour)
+
+	if row.ARN != nil {
+		row.TpAkas = append(row.TpAkas, tables.AwsAkasFromArn(*row.ARN)...)
+	}
+
+	return row, nil
+}
+
+func (t *ConfigConfigurationItemTable) GetDescription() string {
+	return "AWS Config configuration items record the configuration of a supported AWS resource at a point in time. This table provides the configuration history and snapshots delivered by AWS Config, including the resource type, ID and ARN, the capture time and status, the relationships to other resources, and the full resource configuration."
+}
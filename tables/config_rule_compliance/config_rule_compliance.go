@@ -0,0 +1,83 @@
+package config_rule_compliance
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+// EvaluationResultQualifier identifies the rule and resource that were evaluated
+type EvaluationResultQualifier struct {
+	ConfigRuleName *string `json:"config_rule_name,omitempty"`
+	EvaluationMode *string `json:"evaluation_mode,omitempty"`
+	ResourceId     *string `json:"resource_id,omitempty"`
+	ResourceType   *string `json:"resource_type,omitempty"`
+}
+
+// EvaluationResultIdentifier uniquely identifies an evaluation result
+type EvaluationResultIdentifier struct {
+	EvaluationResultQualifier *EvaluationResultQualifier `json:"evaluation_result_qualifier,omitempty"`
+	OrderingTimestamp         *time.Time                 `json:"ordering_timestamp,omitempty"`
+	ResourceEvaluationId      *string                    `json:"resource_evaluation_id,omitempty"`
+}
+
+// EvaluationResult is the result of the evaluation of a resource by a Config rule
+type EvaluationResult struct {
+	Annotation                 *string                     `json:"annotation,omitempty"`
+	ComplianceType             *string                     `json:"compliance_type,omitempty"`
+	ConfigRuleInvokedTime      *time.Time                  `json:"config_rule_invoked_time,omitempty"`
+	EvaluationResultIdentifier *EvaluationResultIdentifier `json:"evaluation_result_identifier,omitempty"`
+	ResultRecordedTime         *time.Time                  `json:"result_recorded_time,omitempty"`
+}
+
+// ConfigRuleCompliance is a change in the compliance of a resource with a Config rule, as reported by
+// a ComplianceChangeNotification
+type ConfigRuleCompliance struct {
+	schema.CommonFields
+
+	Annotation               *string           `json:"annotation,omitempty"`
+	AwsAccountId             *string           `json:"aws_account_id,omitempty"`
+	AwsRegion                *string           `json:"aws_region,omitempty"`
+	ConfigRuleArn            *string           `json:"config_rule_arn,omitempty"`
+	ConfigRuleInvokedTime    *time.Time        `json:"config_rule_invoked_time,omitempty"`
+	ConfigRuleName           *string           `json:"config_rule_name,omitempty"`
+	EvaluationMode           *string           `json:"evaluation_mode,omitempty"`
+	EventId                  *string           `json:"event_id,omitempty"`
+	MessageType              *string           `json:"message_type,omitempty"`
+	NewComplianceType        *string           `json:"new_compliance_type,omitempty"`
+	NewEvaluationResult      *EvaluationResult `json:"new_evaluation_result,omitempty"`
+	NotificationCreationTime *time.Time        `json:"notification_creation_time,omitempty"`
+	OldComplianceType        *string           `json:"old_compliance_type,omitempty"`
+	OldEvaluationResult      *EvaluationResult `json:"old_evaluation_result,omitempty"`
+	RecordVersion            *string           `json:"record_version,omitempty"`
+	ResourceId               *string           `json:"resource_id,omitempty"`
+	ResourceType             *string           `json:"resource_type,omitempty"`
+	ResultRecordedTime       *time.Time        `json:"result_recorded_time,omitempty"`
+}
+
+func (c *ConfigRuleCompliance) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"annotation":                 "Supplementary information about how the evaluation determined the compliance, from the new evaluation result.",
+		"aws_account_id":             "The ID of the AWS account that owns the evaluated resource.",
+		"aws_region":                 "The AWS region of the Config rule.",
+		"config_rule_arn":            "The Amazon Resource Name (ARN) of the Config rule.",
+		"config_rule_invoked_time":   "The time when the Config rule evaluated the resource, from the new evaluation result.",
+		"config_rule_name":           "The name of the Config rule.",
+		"evaluation_mode":            "The mode of the evaluation, DETECTIVE or PROACTIVE.",
+		"event_id":                   "The ID of the EventBridge event that delivered the notification, if delivered by EventBridge.",
+		"message_type":               "The type of the notification, i.e. ComplianceChangeNotification.",
+		"new_compliance_type":        "The compliance of the resource after the evaluation, e.g. COMPLIANT, NON_COMPLIANT or NOT_APPLICABLE.",
+		"new_evaluation_result":      "The new evaluation result, including the compliance type, annotation and evaluation times.",
+		"notification_creation_time": "The time when the notification was created.",
+		"old_compliance_type":        "The compliance of the resource before the evaluation, if it was previously evaluated.",
+		"old_evaluation_result":      "The previous evaluation result, if the resource was previously evaluated.",
+		"record_version":             "The version of the notification record.",
+		"resource_id":                "The ID of the evaluated resource.",
+		"resource_type":              "The type of the evaluated resource, e.g. AWS::S3::Bucket.",
+		"result_recorded_time":       "The time when AWS Config recorded the evaluation result, from the new evaluation result.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARN of the Config rule.",
+		"tp_timestamp": "The time when the notification was created.",
+	}
+}
@@ -0,0 +1,171 @@
+package config_rule_compliance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+const (
+	complianceChangeDetailType  = "Config Rules Compliance Change"
+	complianceChangeMessageType = "ComplianceChangeNotification"
+)
+
+// ConfigRuleComplianceExtractor is an extractor that receives a sequence of JSON serialised
+// Config Rules Compliance Change EventBridge events, or ComplianceChangeNotification messages,
+// and extracts a ConfigRuleCompliance record from each of them
+type ConfigRuleComplianceExtractor struct {
+}
+
+// NewConfigRuleComplianceExtractor creates a new ConfigRuleComplianceExtractor
+func NewConfigRuleComplianceExtractor() artifact_source.Extractor {
+	return &ConfigRuleComplianceExtractor{}
+}
+
+func (c *ConfigRuleComplianceExtractor) Identifier() string {
+	return "config_rule_compliance_extractor"
+}
+
+// Extract decodes each event in the artifact data and returns the ConfigRuleCompliance records,
+// skipping any events which are not compliance change notifications
+func (c *ConfigRuleComplianceExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	objects, err := tables.DecodeJSONObjects(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []any
+	for _, object := range objects {
+		var event tables.EventBridgeEvent
+		if err := json.Unmarshal(object, &event); err != nil {
+			return nil, fmt.Errorf("error decoding event: %w", err)
+		}
+
+		// the notification is either the detail of an EventBridge event, or the whole object (e.g. an SNS message)
+		notificationJSON := object
+		if len(event.Detail) > 0 {
+			if event.DetailType == nil || *event.DetailType != complianceChangeDetailType {
+				continue
+			}
+			notificationJSON = event.Detail
+		}
+
+		var notification complianceChangeNotification
+		if err := json.Unmarshal(notificationJSON, &notification); err != nil {
+			return nil, fmt.Errorf("error decoding compliance change notification: %w", err)
+		}
+		if notification.MessageType == nil || *notification.MessageType != complianceChangeMessageType {
+			continue
+		}
+
+		res = append(res, notification.toRow(event))
+	}
+
+	slog.Debug("ConfigRuleComplianceExtractor", "record count", len(res))
+	return res, nil
+}
+
+// complianceChangeNotification is the ComplianceChangeNotification message sent by AWS Config
+type complianceChangeNotification struct {
+	AwsAccountId             *string                 `json:"awsAccountId"`
+	AwsRegion                *string                 `json:"awsRegion"`
+	ConfigRuleARN            *string                 `json:"configRuleARN"`
+	ConfigRuleName           *string                 `json:"configRuleName"`
+	MessageType              *string                 `json:"messageType"`
+	NewEvaluationResult      *notificationEvaluation `json:"newEvaluationResult"`
+	NotificationCreationTime *time.Time              `json:"notificationCreationTime"`
+	OldEvaluationResult      *notificationEvaluation `json:"oldEvaluationResult"`
+	RecordVersion            *string                 `json:"recordVersion"`
+	ResourceId               *string                 `json:"resourceId"`
+	ResourceType             *string                 `json:"resourceType"`
+}
+
+type notificationEvaluation struct {
+	Annotation                 *string    `json:"annotation"`
+	ComplianceType             *string    `json:"complianceType"`
+	ConfigRuleInvokedTime      *time.Time `json:"configRuleInvokedTime"`
+	EvaluationResultIdentifier *struct {
+		EvaluationResultQualifier *struct {
+			ConfigRuleName *string `json:"configRuleName"`
+			EvaluationMode *string `json:"evaluationMode"`
+			ResourceId     *string `json:"resourceId"`
+			ResourceType   *string `json:"resourceType"`
+		} `json:"evaluationResultQualifier"`
+		OrderingTimestamp    *time.Time `json:"orderingTimestamp"`
+		ResourceEvaluationId *string    `json:"resourceEvaluationId"`
+	} `json:"evaluationResultIdentifier"`
+	ResultRecordedTime *time.Time `json:"resultRecordedTime"`
+}
+
+func (n *notificationEvaluation) toEvaluationResult() *EvaluationResult {
+	if n == nil {
+		return nil
+	}
+	res := &EvaluationResult{
+		Annotation:            n.Annotation,
+		ComplianceType:        n.ComplianceType,
+		ConfigRuleInvokedTime: n.ConfigRuleInvokedTime,
+		ResultRecordedTime:    n.ResultRecordedTime,
+	}
+	if identifier := n.EvaluationResultIdentifier; identifier != nil {
+		res.EvaluationResultIdentifier = &EvaluationResultIdentifier{
+			OrderingTimestamp:    identifier.OrderingTimestamp,
+			ResourceEvaluationId: identifier.ResourceEvaluationId,
+		}
+		if qualifier := identifier.EvaluationResultQualifier; qualifier != nil {
+			res.EvaluationResultIdentifier.EvaluationResultQualifier = &EvaluationResultQualifier{
+				ConfigRuleName: qualifier.ConfigRuleName,
+				EvaluationMode: qualifier.EvaluationMode,
+				ResourceId:     qualifier.ResourceId,
+				ResourceType:   qualifier.ResourceType,
+			}
+		}
+	}
+	return res
+}
+
+func (n *complianceChangeNotification) toRow(event tables.EventBridgeEvent) *ConfigRuleCompliance {
+	row := &ConfigRuleCompliance{
+		AwsAccountId:             n.AwsAccountId,
+		AwsRegion:                n.AwsRegion,
+		ConfigRuleArn:            n.ConfigRuleARN,
+		ConfigRuleName:           n.ConfigRuleName,
+		EventId:                  event.Id,
+		MessageType:              n.MessageType,
+		NewEvaluationResult:      n.NewEvaluationResult.toEvaluationResult(),
+		NotificationCreationTime: n.NotificationCreationTime,
+		OldEvaluationResult:      n.OldEvaluationResult.toEvaluationResult(),
+		RecordVersion:            n.RecordVersion,
+		ResourceId:               n.ResourceId,
+		ResourceType:             n.ResourceType,
+	}
+	// the notification creation time is not always set, so fall back to the time of the event
+	if row.NotificationCreationTime == nil {
+		row.NotificationCreationTime = event.Time
+	}
+
+	if result := row.NewEvaluationResult; result != nil {
+		row.Annotation = result.Annotation
+		row.NewComplianceType = result.ComplianceType
+		row.ConfigRuleInvokedTime = result.ConfigRuleInvokedTime
+		row.ResultRecordedTime = result.ResultRecordedTime
+		if result.EvaluationResultIdentifier != nil && result.EvaluationResultIdentifier.EvaluationResultQualifier != nil {
+			row.EvaluationMode = result.EvaluationResultIdentifier.EvaluationResultQualifier.EvaluationMode
+		}
+	}
+	if row.OldEvaluationResult != nil {
+		row.OldComplianceType = row.OldEvaluationResult.ComplianceType
+	}
+
+	return row
+}
@@ -0,0 +1,77 @@
+package config_rule_compliance
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const ConfigRuleComplianceTableIdentifier = "aws_config_rule_compliance"
+
+// ConfigRuleComplianceTable - table for AWS Config rule compliance change notifications
+type ConfigRuleComplianceTable struct{}
+
+func (c *ConfigRuleComplianceTable) Identifier() string {
+	return ConfigRuleComplianceTableIdentifier
+}
+
+func (c *ConfigRuleComplianceTable) GetSourceMetadata() ([]*table.SourceMetadata[*ConfigRuleCompliance], error) {
+	// compliance change notifications are delivered by an EventBridge rule
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer(tables.FirehoseDefaultFileLayout),
+	}
+
+	return []*table.SourceMetadata[*ConfigRuleCompliance]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewConfigRuleComplianceExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewConfigRuleComplianceExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *ConfigRuleComplianceTable) EnrichRow(row *ConfigRuleCompliance, sourceEnrichmentFields schema.SourceEnrichment) (*ConfigRuleCompliance, error) {
+	if row.NotificationCreationTime == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"notificationCreationTime"}, nil)
+	}
+
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *row.NotificationCreationTime
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = row.NotificationCreationTime.Truncate(24 * time.Hour)
+
+	if row.ConfigRuleArn != nil {
+		row.TpAkas = append(row.TpAkas, *row.ConfigRuleArn)
+	}
+
+	return row, nil
+}
+
+func (c *ConfigRuleComplianceTable) GetDescription() string {
+	return "AWS Config rule compliance changes record each change in the compliance of a resource with a Config rule. This table provides the rule and resource evaluated, the old and new compliance types, the annotation and the evaluation times, allowing compliance drift to be tracked over time."
+}
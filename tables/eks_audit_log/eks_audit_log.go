@@ -0,0 +1,91 @@
+package eks_audit_log
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+// UserInfo holds the information about the user making the request, or the user being impersonated
+type UserInfo struct {
+	Username *string             `json:"username,omitempty"`
+	UID      *string             `json:"uid,omitempty"`
+	Groups   []string            `json:"groups,omitempty"`
+	Extra    map[string][]string `json:"extra,omitempty" parquet:"type=JSON"`
+}
+
+// ObjectReference contains enough information to identify the object the request was made against
+type ObjectReference struct {
+	Resource        *string `json:"resource,omitempty"`
+	Namespace       *string `json:"namespace,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	UID             *string `json:"uid,omitempty"`
+	APIGroup        *string `json:"apiGroup,omitempty"`
+	APIVersion      *string `json:"apiVersion,omitempty"`
+	ResourceVersion *string `json:"resourceVersion,omitempty"`
+	Subresource     *string `json:"subresource,omitempty"`
+}
+
+// ResponseStatus is the status of the response, populated for non-success responses
+type ResponseStatus struct {
+	Code    *int32  `json:"code,omitempty"`
+	Status  *string `json:"status,omitempty"`
+	Message *string `json:"message,omitempty"`
+	Reason  *string `json:"reason,omitempty"`
+}
+
+// EksAuditLog is a Kubernetes API server audit event (audit.k8s.io/v1 Event)
+type EksAuditLog struct {
+	schema.CommonFields
+
+	Annotations              map[string]string       `json:"annotations,omitempty" parquet:"type=JSON"`
+	ApiVersion               *string                 `json:"apiVersion,omitempty" parquet:"name=api_version"`
+	AuditId                  *string                 `json:"auditID,omitempty" parquet:"name=audit_id"`
+	ClusterName              *string                 `json:"clusterName,omitempty" parquet:"name=cluster_name"`
+	ImpersonatedUser         *UserInfo               `json:"impersonatedUser,omitempty" parquet:"name=impersonated_user"`
+	Kind                     *string                 `json:"kind,omitempty"`
+	Level                    *string                 `json:"level,omitempty"`
+	ObjectRef                *ObjectReference        `json:"objectRef,omitempty" parquet:"name=object_ref"`
+	RequestObject            *map[string]interface{} `json:"requestObject,omitempty" parquet:"name=request_object, type=JSON"`
+	RequestReceivedTimestamp *time.Time              `json:"requestReceivedTimestamp,omitempty" parquet:"name=request_received_timestamp"`
+	RequestUri               *string                 `json:"requestURI,omitempty" parquet:"name=request_uri"`
+	ResponseObject           *map[string]interface{} `json:"responseObject,omitempty" parquet:"name=response_object, type=JSON"`
+	ResponseStatus           *ResponseStatus         `json:"responseStatus,omitempty" parquet:"name=response_status"`
+	SourceIps                []string                `json:"sourceIPs,omitempty" parquet:"name=source_ips"`
+	Stage                    *string                 `json:"stage,omitempty"`
+	StageTimestamp           *time.Time              `json:"stageTimestamp,omitempty" parquet:"name=stage_timestamp"`
+	User                     *UserInfo               `json:"user,omitempty"`
+	UserAgent                *string                 `json:"userAgent,omitempty" parquet:"name=user_agent"`
+	Verb                     *string                 `json:"verb,omitempty"`
+}
+
+func (l *EksAuditLog) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"annotations":                "An unstructured key value map stored with the audit event, e.g. the authorization decision and reason.",
+		"api_version":                "The version of the audit event schema, e.g. audit.k8s.io/v1.",
+		"audit_id":                   "The unique audit ID, generated for each request.",
+		"cluster_name":               "The name of the EKS cluster, derived from the /aws/eks/<cluster name>/cluster log group name.",
+		"impersonated_user":          "Information about the user being impersonated, if the request used impersonation.",
+		"kind":                       "The kind of the audit object, always Event.",
+		"level":                      "The audit level at which the event was generated (Metadata, Request or RequestResponse).",
+		"object_ref":                 "A reference to the object that the request was made against, including the resource, namespace and name. Does not apply to non-resource requests.",
+		"request_object":             "The API object from the request, in JSON format. Only recorded at the Request level and higher.",
+		"request_received_timestamp": "The time the request reached the API server.",
+		"request_uri":                "The request URI as sent by the client to the API server.",
+		"response_object":            "The API object returned in the response, in JSON format. Only recorded at the RequestResponse level.",
+		"response_status":            "The response status, including the HTTP status code, populated even when the response object is not recorded.",
+		"source_ips":                 "The source IP addresses the request originated from and any intermediate proxies.",
+		"stage":                      "The stage of request handling when the event was generated (RequestReceived, ResponseStarted, ResponseComplete or Panic).",
+		"stage_timestamp":            "The time the request reached the current audit stage.",
+		"user":                       "Authenticated user information, including the username, groups and extra fields such as the IAM ARN.",
+		"user_agent":                 "The user agent string reported by the client.",
+		"verb":                       "The Kubernetes verb associated with the request, e.g. get, list, create, update, patch or delete.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The IAM ARNs of the user and impersonated user, from the extra user information added by the EKS authenticator.",
+		"tp_ips":       "The source IP addresses of the request.",
+		"tp_source_ip": "The first source IP address of the request.",
+		"tp_timestamp": "The time the request reached the API server.",
+		"tp_usernames": "The usernames of the user and impersonated user, and their IAM ARNs.",
+	}
+}
@@ -0,0 +1,72 @@
+package eks_audit_log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+)
+
+// the prefix of the log streams containing API server audit events in the cluster log group,
+// which also contains streams for the other control plane log types (e.g. kube-apiserver-, authenticator-, kube-scheduler-)
+const auditLogStreamPrefix = "kube-apiserver-audit-"
+
+const auditEventKind = "Event"
+
+// isAuditEvent returns whether a log event is an audit event from an API server audit log stream.
+// Events from the other control plane log streams in the cluster log group, and entries of other kinds,
+// are skipped by the source rather than raising a row error.
+func isAuditEvent(e cwTypes.FilteredLogEvent) bool {
+	if e.LogStreamName != nil && !strings.HasPrefix(*e.LogStreamName, auditLogStreamPrefix) {
+		return false
+	}
+	if e.Message == nil {
+		return false
+	}
+	var entry struct {
+		Kind *string `json:"kind"`
+	}
+	// if the message is not valid JSON, collect it so the mapper reports the error
+	if err := json.Unmarshal([]byte(*e.Message), &entry); err != nil {
+		return true
+	}
+	return entry.Kind != nil && *entry.Kind == auditEventKind
+}
+
+type EksAuditLogMapper struct {
+}
+
+func (m *EksAuditLogMapper) Identifier() string {
+	return "aws_eks_audit_log_mapper"
+}
+
+func (m *EksAuditLogMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*EksAuditLog]) (*EksAuditLog, error) {
+	var jsonBytes []byte
+
+	switch v := a.(type) {
+	case []byte:
+		jsonBytes = v
+	case string:
+		jsonBytes = []byte(v)
+	case *string:
+		jsonBytes = []byte(*v)
+	case cwTypes.FilteredLogEvent:
+		jsonBytes = []byte(*v.Message)
+	default:
+		return nil, fmt.Errorf("expected byte[] or string, got %T", a)
+	}
+
+	var log EksAuditLog
+	if err := json.Unmarshal(jsonBytes, &log); err != nil {
+		return nil, fmt.Errorf("error decoding JSON: %w", err)
+	}
+	if log.RequestReceivedTimestamp == nil && log.StageTimestamp == nil {
+		return nil, fmt.Errorf("audit event has no timestamp")
+	}
+
+	return &log, nil
+}
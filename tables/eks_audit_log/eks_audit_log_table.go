@@ -0,0 +1,101 @@
+package eks_audit_log
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const EksAuditLogTableIdentifier = "aws_eks_audit_log"
+
+// control plane logs are delivered to the /aws/eks/<cluster name>/cluster log group
+var clusterLogGroupRegex = regexp.MustCompile(`^/aws/eks/([^/]+)/cluster$`)
+
+// the keys of the extra user information, added by the EKS authenticator, which contain IAM ARNs
+var iamArnExtraKeys = []string{"arn", "canonicalArn"}
+
+// EksAuditLogTable - table for EKS Kubernetes API server audit logs
+type EksAuditLogTable struct{}
+
+func (c *EksAuditLogTable) Identifier() string {
+	return EksAuditLogTableIdentifier
+}
+
+func (c *EksAuditLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*EksAuditLog], error) {
+	return []*table.SourceMetadata[*EksAuditLog]{
+		{
+			// CloudWatch source
+			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
+			Mapper:     &EksAuditLogMapper{},
+			Options: []row_source.RowSourceOption{
+				// only collect the audit log streams, unless log_stream_names is configured
+				cloudwatch_log_group.WithDefaultLogStreamNames(auditLogStreamPrefix + "*"),
+				// skip any other events without raising an error, e.g. if log_stream_names includes other streams
+				cloudwatch_log_group.WithLogEventFilter(isAuditEvent),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *EksAuditLogTable) EnrichRow(row *EksAuditLog, sourceEnrichmentFields schema.SourceEnrichment) (*EksAuditLog, error) {
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	timestamp := row.RequestReceivedTimestamp
+	if timestamp == nil {
+		timestamp = row.StageTimestamp
+	}
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *timestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = timestamp.Truncate(24 * time.Hour)
+
+	// the source name is the log group name
+	if row.TpSourceName != nil {
+		if match := clusterLogGroupRegex.FindStringSubmatch(*row.TpSourceName); match != nil {
+			row.ClusterName = &match[1]
+		}
+	}
+
+	if len(row.SourceIps) > 0 {
+		row.TpSourceIP = &row.SourceIps[0]
+		row.TpIps = append(row.TpIps, row.SourceIps...)
+	}
+
+	for _, user := range []*UserInfo{row.User, row.ImpersonatedUser} {
+		if user == nil {
+			continue
+		}
+		if user.Username != nil && !slices.Contains(row.TpUsernames, *user.Username) {
+			row.TpUsernames = append(row.TpUsernames, *user.Username)
+		}
+		for _, key := range iamArnExtraKeys {
+			for _, arn := range user.Extra[key] {
+				if !strings.HasPrefix(arn, "arn:") {
+					continue
+				}
+				if !slices.Contains(row.TpUsernames, arn) {
+					row.TpUsernames = append(row.TpUsernames, arn)
+				}
+				if !slices.Contains(row.TpAkas, arn) {
+					row.TpAkas = append(row.TpAkas, arn)
+				}
+			}
+		}
+	}
+
+	return row, nil
+}
+
+func (c *EksAuditLogTable) GetDescription() string {
+	return "AWS EKS audit logs record the requests made to the Kubernetes API server of an EKS cluster. This table provides details of each request, including the verb, user and impersonated user, target object and response status, helping teams audit Kubernetes RBAC activity and investigate access to cluster resources."
+}
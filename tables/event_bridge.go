@@ -0,0 +1,48 @@
+package tables
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// FirehoseDefaultFileLayout is the default object key format of Amazon Data Firehose, which EventBridge rules
+// typically deliver events to S3 through. AWS does not define an S3 file layout for events delivered this way.
+const FirehoseDefaultFileLayout = "%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/%{HOUR:hour}/%{DATA}"
+
+// EventBridgeEvent is the envelope of an Amazon EventBridge event. The service specific content of the
+// event is left as raw JSON in Detail, to be decoded by the table.
+type EventBridgeEvent struct {
+	Account    *string         `json:"account,omitempty"`
+	Detail     json.RawMessage `json:"detail,omitempty"`
+	DetailType *string         `json:"detail-type,omitempty"`
+	Id         *string         `json:"id,omitempty"`
+	Region     *string         `json:"region,omitempty"`
+	Resources  []string        `json:"resources,omitempty"`
+	Source     *string         `json:"source,omitempty"`
+	Time       *time.Time      `json:"time,omitempty"`
+	Version    *string         `json:"version,omitempty"`
+}
+
+// DecodeJSONObjects splits data containing a sequence of JSON objects into the individual objects.
+// The objects may be newline delimited or concatenated with no delimiter, as written by Amazon Data Firehose
+// when delivering EventBridge events to S3 without a record separator.
+func DecodeJSONObjects(data []byte) ([]json.RawMessage, error) {
+	var res []json.RawMessage
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var object json.RawMessage
+		err := decoder.Decode(&object)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding json: %w", err)
+		}
+		res = append(res, object)
+	}
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package tables
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+// flowLogFieldRegex matches the name of a flow log field, e.g. account-id - records always contain numeric values,
+// so never consist only of field names
+var flowLogFieldRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// SplitFlowLogHeader splits the space-separated content of a flow log file (VPC or Transit Gateway) into lines.
+// If the first line is a header containing only valid fields, the header fields and the remaining lines are returned.
+// Otherwise the returned fields are nil, and all lines are returned as records.
+func SplitFlowLogHeader(data string, validFields map[string]string) ([]string, []string, error) {
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	if len(lines) < 2 {
+		return nil, nil, fmt.Errorf("insufficient data: needs at least a header and one row")
+	}
+
+	header := strings.Fields(lines[0])
+	for _, field := range header {
+		if _, ok := validFields[field]; !ok {
+			return nil, lines, nil
+		}
+	}
+	return header, lines[1:], nil
+}
+
+// FlowLogRows returns a DynamicRow for each flow log record, mapping the space-separated values of the record
+// to the column names of the given fields. Values equal to nilValue are omitted.
+func FlowLogRows(fields []string, records []string, columnNames map[string]string, nilValue string) ([]any, error) {
+	res := make([]any, 0, len(records))
+
+	for _, record := range records {
+		values := strings.Fields(record)
+		rowColumnMap := make(map[string]string)
+
+		for i, field := range fields {
+			columnName, ok := columnNames[field]
+			if !ok {
+				continue
+			}
+			// assign an empty string if the value is missing
+			var value string
+			if i < len(values) {
+				value = values[i]
+			}
+			if value != nilValue {
+				rowColumnMap[columnName] = value
+			}
+		}
+
+		row := &types.DynamicRow{}
+		if err := row.InitialiseFromMap(rowColumnMap); err != nil {
+			return nil, fmt.Errorf("error initialising dynamic row: %w", err)
+		}
+		res = append(res, row)
+	}
+
+	return res, nil
+}
+
+// IsFlowLogHeader returns whether a line of a flow log file is a header, i.e. it consists only of field names.
+// This allows a header of an unrecognised format to be distinguished from a record, for files with no header.
+func IsFlowLogHeader(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, field := range fields {
+		if !flowLogFieldRegex.MatchString(field) {
+			return false
+		}
+	}
+	return true
+}
@@ -4,11 +4,13 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/guardduty/types"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
 type GuardDutyFinding struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	AccountId     *string    `json:"account_id"`
 	Arn           *string    `json:"arn"`
@@ -117,7 +119,7 @@ type AccessKeyDetails struct {
 }
 
 func (c *GuardDutyFinding) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"account_id":     "The AWS account ID where the finding was generated.",
 		"arn":            "The Amazon Resource Name (ARN) of the finding.",
 		"description":    "A detailed description of the security finding, including what was detected and its potential impact.",
@@ -137,5 +139,5 @@ func (c *GuardDutyFinding) GetColumnDescriptions() map[string]string {
 		"tp_akas":      "The Amazon Resource Names (ARNs) associated with the finding.",
 		"tp_index":     "The AWS account ID where the finding was generated.",
 		"tp_usernames": "Usernames associated with the finding, including IAM users and access key IDs.",
-	}
+	})
 }
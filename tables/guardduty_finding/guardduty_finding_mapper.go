@@ -24,14 +24,19 @@ func (g *GuardDutyMapper) Identifier() string {
 // Map casts the data item as a GuardDutyBatch and returns the GuardDutyFinding records
 func (g *GuardDutyMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*GuardDutyFinding]) (*GuardDutyFinding, error) {
 	var jsonBytes []byte
-	// The expected input type is a JSON byte[] deserializable to GuardDutyBatch
+	// The expected input type is a JSON byte[] deserializable to GuardDutyBatch,
+	// or a types.Finding returned by the GuardDuty GetFindings API
 	switch v := a.(type) {
 	case []byte:
 		jsonBytes = v
 	case string:
 		jsonBytes = []byte(v)
+	case types.Finding:
+		return g.mapFinding(v)
+	case *types.Finding:
+		return g.mapFinding(*v)
 	default:
-		return nil, fmt.Errorf("expected byte[] or string, got %T", a)
+		return nil, fmt.Errorf("expected byte[], string or types.Finding, got %T", a)
 	}
 
 	// pre-process JSON for compatibility issue(s) with AWS SDK https://github.com/aws/aws-sdk-go-v2/issues/2145
@@ -44,6 +49,13 @@ func (g *GuardDutyMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*
 		return nil, fmt.Errorf("error decoding JSON to findings: %w", err)
 	}
 
+	return g.mapFinding(finding)
+}
+
+// mapFinding populates a GuardDutyFinding from an AWS SDK types.Finding
+func (g *GuardDutyMapper) mapFinding(finding types.Finding) (*GuardDutyFinding, error) {
+	var err error
+
 	// Populate `GuardDutyFinding` instance with values from `types.Finding`
 	row := &GuardDutyFinding{
 		AccountId:     finding.AccountId,
@@ -6,6 +6,7 @@ import (
 	"github.com/rs/xid"
 
 	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/guardduty"
 	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
@@ -46,11 +47,20 @@ func (c *GuardDutyFindingTable) GetSourceMetadata() ([]*table.SourceMetadata[*Gu
 				artifact_source.WithRowPerLine(),
 			},
 		},
+		{
+			SourceName: guardduty.AwsGuardDutySourceIdentifier,
+			Mapper:     &GuardDutyMapper{},
+		},
 	}, nil
 }
 
 func (c *GuardDutyFindingTable) EnrichRow(row *GuardDutyFinding, sourceEnrichmentFields schema.SourceEnrichment) (*GuardDutyFinding, error) {
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+	// the default layout captures the region as region_path
+	if regionPath, ok := sourceEnrichmentFields.Metadata["region_path"]; ok && row.SourceRegion == nil {
+		row.SourceRegion = &regionPath
+	}
 	row.TpID = xid.New().String()
 	row.TpTimestamp = row.CreatedAt
 	row.TpIngestTimestamp = time.Now()
@@ -0,0 +1,66 @@
+package health_event
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type HealthEvent struct {
+	schema.CommonFields
+
+	AccountId            *string                 `json:"account_id,omitempty"`
+	AffectedAccount      *string                 `json:"affected_account,omitempty"`
+	AffectedEntities     []AffectedEntity        `json:"affected_entities,omitempty" parquet:"type=JSON"`
+	AffectedEntityValues []string                `json:"affected_entity_values,omitempty"`
+	CommunicationId      *string                 `json:"communication_id,omitempty"`
+	EndTime              *time.Time              `json:"end_time,omitempty"`
+	EventArn             *string                 `json:"event_arn,omitempty"`
+	EventDescription     *string                 `json:"event_description,omitempty"`
+	EventId              *string                 `json:"event_id,omitempty"`
+	EventMetadata        *map[string]interface{} `json:"event_metadata,omitempty" parquet:"type=JSON"`
+	EventRegion          *string                 `json:"event_region,omitempty"`
+	EventScopeCode       *string                 `json:"event_scope_code,omitempty"`
+	EventTime            *time.Time              `json:"event_time,omitempty"`
+	EventTypeCategory    *string                 `json:"event_type_category,omitempty"`
+	EventTypeCode        *string                 `json:"event_type_code,omitempty"`
+	LastUpdatedTime      *time.Time              `json:"last_updated_time,omitempty"`
+	Service              *string                 `json:"service,omitempty"`
+	StartTime            *time.Time              `json:"start_time,omitempty"`
+	StatusCode           *string                 `json:"status_code,omitempty"`
+}
+
+type AffectedEntity struct {
+	EntityValue     *string            `json:"entity_value,omitempty"`
+	LastUpdatedTime *time.Time         `json:"last_updated_time,omitempty"`
+	Status          *string            `json:"status,omitempty"`
+	Tags            *map[string]string `json:"tags,omitempty"`
+}
+
+func (e *HealthEvent) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"account_id":             "The AWS account ID that the EventBridge event was delivered to.",
+		"affected_account":       "The AWS account ID affected by the event. Only present for account-specific events.",
+		"affected_entities":      "The entities affected by the event, e.g. EC2 instance IDs or ARNs, with their status and tags.",
+		"affected_entity_values": "The IDs or ARNs of the entities affected by the event.",
+		"communication_id":       "The unique identifier of the communication for the event, shared by all updates of the event.",
+		"end_time":               "The date and time that the event ended.",
+		"event_arn":              "The Amazon Resource Name (ARN) of the Health event.",
+		"event_description":      "The latest description of the event, in English where available.",
+		"event_id":               "The unique identifier of the EventBridge event.",
+		"event_metadata":         "Additional metadata about the event, e.g. the scheduled maintenance window.",
+		"event_region":           "The AWS region of the event.",
+		"event_scope_code":       "The scope of the event (PUBLIC, ACCOUNT_SPECIFIC or NONE).",
+		"event_time":             "The date and time of the EventBridge event.",
+		"event_type_category":    "The category of the event type (issue, accountNotification, scheduledChange or investigation).",
+		"event_type_code":        "The unique identifier of the event type, e.g. AWS_EC2_OPERATIONAL_ISSUE or AWS_EC2_INSTANCE_REBOOT_MAINTENANCE_SCHEDULED.",
+		"last_updated_time":      "The date and time that the event was last updated.",
+		"service":                "The AWS service affected by the event, e.g. EC2.",
+		"start_time":             "The date and time that the event began.",
+		"status_code":            "The status of the event (open, closed or upcoming).",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARN of the Health event and the ARNs of the affected entities.",
+		"tp_timestamp": "The date and time that the event was last updated.",
+	}
+}
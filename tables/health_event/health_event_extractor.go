@@ -0,0 +1,253 @@
+package health_event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+const healthEventSource = "aws.health"
+
+// HealthEventExtractor is an extractor that receives a sequence of JSON serialised AWS Health EventBridge events,
+// or an export of organizational Health events, and extracts a HealthEvent record for each event
+type HealthEventExtractor struct {
+}
+
+// NewHealthEventExtractor creates a new HealthEventExtractor
+func NewHealthEventExtractor() artifact_source.Extractor {
+	return &HealthEventExtractor{}
+}
+
+func (c *HealthEventExtractor) Identifier() string {
+	return "health_event_extractor"
+}
+
+// Extract decodes each event in the artifact data and returns the HealthEvent records,
+// skipping any EventBridge events which are not from AWS Health
+func (c *HealthEventExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	objects, err := tables.DecodeJSONObjects(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []any
+	for _, object := range objects {
+		var envelope struct {
+			tables.EventBridgeEvent
+			// the result of DescribeEventDetailsForOrganization, as exported for organizational Health events
+			SuccessfulSet []organizationEventDetails `json:"successfulSet"`
+		}
+		if err := json.Unmarshal(object, &envelope); err != nil {
+			return nil, fmt.Errorf("error decoding event: %w", err)
+		}
+
+		switch {
+		case envelope.SuccessfulSet != nil:
+			for _, details := range envelope.SuccessfulSet {
+				res = append(res, details.toRow())
+			}
+		case len(envelope.Detail) > 0:
+			if envelope.Source == nil || *envelope.Source != healthEventSource {
+				continue
+			}
+			var detail healthEventDetail
+			if err := json.Unmarshal(envelope.Detail, &detail); err != nil {
+				return nil, fmt.Errorf("error decoding health event: %w", err)
+			}
+			res = append(res, detail.toRow(envelope.EventBridgeEvent))
+		default:
+			// a Health event detail which is not wrapped in an EventBridge event
+			var detail healthEventDetail
+			if err := json.Unmarshal(object, &detail); err != nil {
+				return nil, fmt.Errorf("error decoding health event: %w", err)
+			}
+			if detail.EventArn == nil {
+				continue
+			}
+			res = append(res, detail.toRow(tables.EventBridgeEvent{}))
+		}
+	}
+
+	slog.Debug("HealthEventExtractor", "record count", len(res))
+	return res, nil
+}
+
+// healthEventDetail is the detail of an AWS Health EventBridge event
+type healthEventDetail struct {
+	AffectedAccount   *string                 `json:"affectedAccount"`
+	AffectedEntities  []affectedEntity        `json:"affectedEntities"`
+	CommunicationId   *string                 `json:"communicationId"`
+	EndTime           *healthTime             `json:"endTime"`
+	EventArn          *string                 `json:"eventArn"`
+	EventDescription  eventDescriptions       `json:"eventDescription"`
+	EventMetadata     *map[string]interface{} `json:"eventMetadata"`
+	EventRegion       *string                 `json:"eventRegion"`
+	EventScopeCode    *string                 `json:"eventScopeCode"`
+	EventTypeCategory *string                 `json:"eventTypeCategory"`
+	EventTypeCode     *string                 `json:"eventTypeCode"`
+	LastUpdatedTime   *healthTime             `json:"lastUpdatedTime"`
+	Service           *string                 `json:"service"`
+	StartTime         *healthTime             `json:"startTime"`
+	StatusCode        *string                 `json:"statusCode"`
+}
+
+type affectedEntity struct {
+	EntityValue     *string            `json:"entityValue"`
+	LastUpdatedTime *healthTime        `json:"lastUpdatedTime"`
+	Status          *string            `json:"status"`
+	Tags            *map[string]string `json:"tags"`
+}
+
+func (d *healthEventDetail) toRow(event tables.EventBridgeEvent) *HealthEvent {
+	row := &HealthEvent{
+		AccountId:         event.Account,
+		AffectedAccount:   d.AffectedAccount,
+		CommunicationId:   d.CommunicationId,
+		EndTime:           d.EndTime.toTime(),
+		EventArn:          d.EventArn,
+		EventDescription:  d.EventDescription.latest(),
+		EventId:           event.Id,
+		EventMetadata:     d.EventMetadata,
+		EventRegion:       d.EventRegion,
+		EventScopeCode:    d.EventScopeCode,
+		EventTime:         event.Time,
+		EventTypeCategory: d.EventTypeCategory,
+		EventTypeCode:     d.EventTypeCode,
+		LastUpdatedTime:   d.LastUpdatedTime.toTime(),
+		Service:           d.Service,
+		StartTime:         d.StartTime.toTime(),
+		StatusCode:        d.StatusCode,
+	}
+	if row.EventRegion == nil {
+		row.EventRegion = event.Region
+	}
+
+	for _, entity := range d.AffectedEntities {
+		row.AffectedEntities = append(row.AffectedEntities, AffectedEntity{
+			EntityValue:     entity.EntityValue,
+			LastUpdatedTime: entity.LastUpdatedTime.toTime(),
+			Status:          entity.Status,
+			Tags:            entity.Tags,
+		})
+		if entity.EntityValue != nil {
+			row.AffectedEntityValues = append(row.AffectedEntityValues, *entity.EntityValue)
+		}
+	}
+
+	return row
+}
+
+// organizationEventDetails is an item of the result of DescribeEventDetailsForOrganization
+type organizationEventDetails struct {
+	AwsAccountId *string `json:"awsAccountId"`
+	Event        struct {
+		Arn               *string     `json:"arn"`
+		EndTime           *healthTime `json:"endTime"`
+		EventScopeCode    *string     `json:"eventScopeCode"`
+		EventTypeCategory *string     `json:"eventTypeCategory"`
+		EventTypeCode     *string     `json:"eventTypeCode"`
+		LastUpdatedTime   *healthTime `json:"lastUpdatedTime"`
+		Region            *string     `json:"region"`
+		Service           *string     `json:"service"`
+		StartTime         *healthTime `json:"startTime"`
+		StatusCode        *string     `json:"statusCode"`
+	} `json:"event"`
+	EventDescription eventDescriptions       `json:"eventDescription"`
+	EventMetadata    *map[string]interface{} `json:"eventMetadata"`
+}
+
+func (d *organizationEventDetails) toRow() *HealthEvent {
+	return &HealthEvent{
+		AffectedAccount:   d.AwsAccountId,
+		EndTime:           d.Event.EndTime.toTime(),
+		EventArn:          d.Event.Arn,
+		EventDescription:  d.EventDescription.latest(),
+		EventMetadata:     d.EventMetadata,
+		EventRegion:       d.Event.Region,
+		EventScopeCode:    d.Event.EventScopeCode,
+		EventTypeCategory: d.Event.EventTypeCategory,
+		EventTypeCode:     d.Event.EventTypeCode,
+		LastUpdatedTime:   d.Event.LastUpdatedTime.toTime(),
+		Service:           d.Event.Service,
+		StartTime:         d.Event.StartTime.toTime(),
+		StatusCode:        d.Event.StatusCode,
+	}
+}
+
+// eventDescriptions is the description of a Health event, which is a list of descriptions by language in
+// EventBridge events, or a single description in the Health API
+type eventDescriptions []struct {
+	Language          *string `json:"language"`
+	LatestDescription *string `json:"latestDescription"`
+}
+
+func (d *eventDescriptions) UnmarshalJSON(data []byte) error {
+	type descriptions eventDescriptions
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		data = append(append([]byte{'['}, trimmed...), ']')
+	}
+	return json.Unmarshal(data, (*descriptions)(d))
+}
+
+// latest returns the latest description in English, or in the first language if there is no English description
+func (d eventDescriptions) latest() *string {
+	for _, description := range d {
+		if description.Language == nil || strings.HasPrefix(*description.Language, "en") {
+			return description.LatestDescription
+		}
+	}
+	if len(d) > 0 {
+		return d[0].LatestDescription
+	}
+	return nil
+}
+
+// healthTime is a timestamp of a Health event. EventBridge events format timestamps as RFC 1123,
+// e.g. "Thu, 01 Jun 2023 13:01:39 GMT", while exports of the Health API use RFC 3339 or epoch seconds.
+type healthTime time.Time
+
+func (t *healthTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		// not a string, so an epoch timestamp in seconds
+		seconds, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp %s", data)
+		}
+		*t = healthTime(time.UnixMilli(int64(seconds * 1000)).UTC())
+		return nil
+	}
+
+	for _, layout := range []string{time.RFC1123, time.RFC1123Z, time.RFC3339Nano} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			*t = healthTime(parsed.UTC())
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid timestamp %q", value)
+}
+
+func (t *healthTime) toTime() *time.Time {
+	if t == nil {
+		return nil
+	}
+	res := time.Time(*t)
+	return &res
+}
@@ -0,0 +1,92 @@
+package health_event
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const HealthEventTableIdentifier = "aws_health_event"
+
+// HealthEventTable - table for AWS Health events
+type HealthEventTable struct{}
+
+func (c *HealthEventTable) Identifier() string {
+	return HealthEventTableIdentifier
+}
+
+func (c *HealthEventTable) GetSourceMetadata() ([]*table.SourceMetadata[*HealthEvent], error) {
+	// AWS Health events are delivered by an EventBridge rule
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer(tables.FirehoseDefaultFileLayout),
+	}
+
+	return []*table.SourceMetadata[*HealthEvent]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewHealthEventExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewHealthEventExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *HealthEventTable) EnrichRow(row *HealthEvent, sourceEnrichmentFields schema.SourceEnrichment) (*HealthEvent, error) {
+	// use the time the event was last updated, so that each update of an event is recorded at the time it was made
+	var timestamp *time.Time
+	for _, t := range []*time.Time{row.LastUpdatedTime, row.EventTime, row.StartTime} {
+		if t != nil {
+			timestamp = t
+			break
+		}
+	}
+	if timestamp == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"lastUpdatedTime"}, nil)
+	}
+
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *timestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = timestamp.Truncate(24 * time.Hour)
+
+	if row.EventArn != nil {
+		row.TpAkas = append(row.TpAkas, *row.EventArn)
+	}
+	for _, value := range row.AffectedEntityValues {
+		if strings.HasPrefix(value, "arn:") && !slices.Contains(row.TpAkas, value) {
+			row.TpAkas = append(row.TpAkas, value)
+		}
+	}
+
+	return row, nil
+}
+
+func (c *HealthEventTable) GetDescription() string {
+	return "AWS Health events report service issues, scheduled changes and account notifications that may affect your AWS resources. This table provides the service, event type, affected entities, start and end times and descriptions of each event, allowing outages and scheduled maintenance to be correlated with your other logs."
+}
@@ -0,0 +1,73 @@
+package inspector_finding
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/inspector2/types"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type InspectorFinding struct {
+	schema.CommonFields
+
+	AwsAccountId                *string                            `json:"aws_account_id,omitempty"`
+	CodeVulnerabilityDetails    *types.CodeVulnerabilityDetails    `json:"code_vulnerability_details,omitempty" parquet:"type=JSON"`
+	CvssScore                   *float64                           `json:"cvss_score,omitempty"`
+	Description                 *string                            `json:"description,omitempty"`
+	EpssScore                   *float64                           `json:"epss_score,omitempty"`
+	ExploitAvailable            *string                            `json:"exploit_available,omitempty"`
+	FindingArn                  *string                            `json:"finding_arn,omitempty"`
+	FirstObservedAt             *time.Time                         `json:"first_observed_at,omitempty"`
+	FixAvailable                *string                            `json:"fix_available,omitempty"`
+	InspectorScore              *float64                           `json:"inspector_score,omitempty"`
+	InspectorScoreDetails       *types.InspectorScoreDetails       `json:"inspector_score_details,omitempty" parquet:"type=JSON"`
+	LastKnownExploitAt          *time.Time                         `json:"last_known_exploit_at,omitempty"`
+	LastObservedAt              *time.Time                         `json:"last_observed_at,omitempty"`
+	NetworkReachabilityDetails  *types.NetworkReachabilityDetails  `json:"network_reachability_details,omitempty" parquet:"type=JSON"`  // contains []struct
+	PackageVulnerabilityDetails *types.PackageVulnerabilityDetails `json:"package_vulnerability_details,omitempty" parquet:"type=JSON"` // contains []struct
+	RelatedVulnerabilities      []string                           `json:"related_vulnerabilities,omitempty"`
+	Remediation                 *types.Remediation                 `json:"remediation,omitempty" parquet:"type=JSON"`
+	Resources                   []types.Resource                   `json:"resources,omitempty" parquet:"type=JSON"`
+	Severity                    *string                            `json:"severity,omitempty"`
+	Status                      *string                            `json:"status,omitempty"`
+	Title                       *string                            `json:"title,omitempty"`
+	Type                        *string                            `json:"type,omitempty"`
+	UpdatedAt                   *time.Time                         `json:"updated_at,omitempty"`
+	VulnerabilityId             *string                            `json:"vulnerability_id,omitempty"`
+	VulnerablePackages          []types.VulnerablePackage          `json:"vulnerable_packages,omitempty" parquet:"type=JSON"`
+}
+
+func (f *InspectorFinding) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"aws_account_id":                "The AWS account ID associated with the finding.",
+		"code_vulnerability_details":    "The details of a code vulnerability in a Lambda function, including the CWEs, detector and file path. Only present for code vulnerability findings.",
+		"cvss_score":                    "The highest CVSS base score of the vulnerability from the vulnerability source, e.g. NVD.",
+		"description":                   "The description of the finding.",
+		"epss_score":                    "The Exploit Prediction Scoring System (EPSS) score of the vulnerability.",
+		"exploit_available":             "Whether a known exploit exists for the vulnerability (YES or NO).",
+		"finding_arn":                   "The Amazon Resource Name (ARN) of the finding.",
+		"first_observed_at":             "The date and time that the finding was first observed.",
+		"fix_available":                 "Whether a fix is available for the vulnerable packages (YES, NO or PARTIAL).",
+		"inspector_score":               "The Amazon Inspector score, which adjusts the CVSS base score for the environment of the resource.",
+		"inspector_score_details":       "The details of the Amazon Inspector score, including the adjusted CVSS score and the adjustments made.",
+		"last_known_exploit_at":         "The date and time of the last known exploit of the vulnerability.",
+		"last_observed_at":              "The date and time that the finding was last observed.",
+		"network_reachability_details":  "The details of a network reachability finding, including the open port range, protocol and network path. Only present for network reachability findings.",
+		"package_vulnerability_details": "The details of a package vulnerability finding, including the vulnerability ID, CVSS scores, reference URLs and vulnerable packages. Only present for package vulnerability findings.",
+		"related_vulnerabilities":       "The IDs of vulnerabilities related to the finding's vulnerability.",
+		"remediation":                   "The recommended remediation for the finding.",
+		"resources":                     "The resources affected by the finding, including the EC2 instance, ECR container image or Lambda function details.",
+		"severity":                      "The severity of the finding (INFORMATIONAL, LOW, MEDIUM, HIGH, CRITICAL or UNTRIAGED).",
+		"status":                        "The status of the finding (ACTIVE, SUPPRESSED or CLOSED).",
+		"title":                         "The title of the finding.",
+		"type":                          "The type of the finding (PACKAGE_VULNERABILITY, NETWORK_REACHABILITY or CODE_VULNERABILITY).",
+		"updated_at":                    "The date and time that the finding was last updated.",
+		"vulnerability_id":              "The ID of the vulnerability, e.g. a CVE ID.",
+		"vulnerable_packages":           "The packages affected by the vulnerability, including the installed version, fixed in version and package manager.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARN of the finding and the ARNs of the affected resources.",
+		"tp_ips":       "The IP addresses of the affected EC2 instances.",
+		"tp_timestamp": "The date and time that the finding was last updated.",
+	}
+}
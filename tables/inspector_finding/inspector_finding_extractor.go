@@ -0,0 +1,399 @@
+package inspector_finding
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/inspector2/types"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+const inspectorFindingDetailType = "Inspector2 Finding"
+
+// the time format used by the timestamps of EventBridge Inspector2 Finding events, e.g. "Jan 19, 2023, 10:46:15 PM"
+const eventTimestampLayout = "Jan 2, 2006, 3:04:05 PM"
+
+// InspectorFindingExtractor is an extractor that receives the content of an Amazon Inspector findings report
+// (JSON or CSV), or a sequence of JSON serialised Inspector2 Finding EventBridge events, and extracts an
+// InspectorFinding record for each finding
+type InspectorFindingExtractor struct {
+}
+
+// NewInspectorFindingExtractor creates a new InspectorFindingExtractor
+func NewInspectorFindingExtractor() artifact_source.Extractor {
+	return &InspectorFindingExtractor{}
+}
+
+func (c *InspectorFindingExtractor) Identifier() string {
+	return "inspector_finding_extractor"
+}
+
+// Extract decodes the findings in the artifact data and returns the InspectorFinding records
+func (c *InspectorFindingExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	data, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	var findings []types.Finding
+	var err error
+	// a JSON report or event stream starts with an object, anything else is treated as a CSV report
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		findings, err = findingsFromJSON(trimmed)
+	} else {
+		findings, err = findingsFromCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]any, 0, len(findings))
+	for _, finding := range findings {
+		res = append(res, newInspectorFinding(finding))
+	}
+
+	slog.Debug("InspectorFindingExtractor", "record count", len(res))
+	return res, nil
+}
+
+// findingsFromJSON decodes the findings from a JSON findings report (an object containing a findings array),
+// or from a sequence of EventBridge events or raw findings, skipping any events which are not Inspector findings
+func findingsFromJSON(data []byte) ([]types.Finding, error) {
+	objects, err := tables.DecodeJSONObjects(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var findingsJSON []json.RawMessage
+	for _, object := range objects {
+		var envelope struct {
+			tables.EventBridgeEvent
+			Findings []json.RawMessage `json:"findings"`
+		}
+		if err := json.Unmarshal(object, &envelope); err != nil {
+			return nil, fmt.Errorf("error decoding findings: %w", err)
+		}
+
+		switch {
+		case envelope.Findings != nil:
+			findingsJSON = append(findingsJSON, envelope.Findings...)
+		case len(envelope.Detail) > 0:
+			if envelope.DetailType == nil || *envelope.DetailType != inspectorFindingDetailType {
+				continue
+			}
+			findingsJSON = append(findingsJSON, envelope.Detail)
+		default:
+			findingsJSON = append(findingsJSON, object)
+		}
+	}
+
+	findings := make([]types.Finding, 0, len(findingsJSON))
+	for _, findingJSON := range findingsJSON {
+		findingJSON, err = normaliseTimestamps(findingJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding finding: %w", err)
+		}
+
+		var finding types.Finding
+		if err := json.Unmarshal(findingJSON, &finding); err != nil {
+			return nil, fmt.Errorf("error decoding finding: %w", err)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// normaliseTimestamps converts the timestamps of a finding to RFC 3339, so it can be decoded into the AWS SDK type.
+// The timestamps of EventBridge events are formatted as e.g. "Jan 19, 2023, 10:46:15 PM", and some exports use epoch numbers.
+func normaliseTimestamps(input json.RawMessage) (json.RawMessage, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	normaliseTimestampValues(value)
+	return json.Marshal(value)
+}
+
+func normaliseTimestampValues(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if strings.HasSuffix(key, "At") {
+				if t, ok := parseTimestamp(child); ok {
+					v[key] = t.Format(time.RFC3339Nano)
+					continue
+				}
+			}
+			normaliseTimestampValues(child)
+		}
+	case []any:
+		for _, child := range v {
+			normaliseTimestampValues(child)
+		}
+	}
+}
+
+func parseTimestamp(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return time.Time{}, false
+		}
+		if t, err := time.Parse(eventTimestampLayout, v); err == nil {
+			return t, true
+		}
+	case json.Number:
+		epoch, err := v.Float64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		// epoch timestamps may be in seconds or milliseconds
+		if epoch > 1e12 {
+			return time.UnixMilli(int64(epoch)).UTC(), true
+		}
+		return time.Unix(int64(epoch), 0).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// the column of the CSV findings report which identifies the finding
+const csvFindingArnColumn = "Finding ARN"
+
+// findingsFromCSV decodes the findings from a CSV findings report. The CSV report contains a subset of the finding
+// details, with one column per field and a header row with the column names, e.g. "Finding ARN".
+func findingsFromCSV(data []byte) ([]types.Finding, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[csvColumnKey(name)] = i
+	}
+	// anything which is not JSON is parsed as CSV, so check this is a findings report
+	if _, ok := columns[csvColumnKey(csvFindingArnColumn)]; !ok {
+		return nil, fmt.Errorf("artifact is not an Inspector findings report: expected JSON, or CSV with a '%s' column", csvFindingArnColumn)
+	}
+
+	var findings []types.Finding
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV record: %w", err)
+		}
+
+		field := func(name string) *string {
+			if i, ok := columns[csvColumnKey(name)]; ok && i < len(record) && record[i] != "" {
+				return &record[i]
+			}
+			return nil
+		}
+		findings = append(findings, findingFromCSVRecord(field))
+	}
+	return findings, nil
+}
+
+// csvColumnKey normalises a CSV column name for lookup, ignoring case, spaces and punctuation
+func csvColumnKey(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func findingFromCSVRecord(field func(string) *string) types.Finding {
+	finding := types.Finding{
+		AwsAccountId:     field("AWS Account Id"),
+		Description:      field("Description"),
+		FindingArn:       field(csvFindingArnColumn),
+		FirstObservedAt:  csvTime(field("First Seen")),
+		LastObservedAt:   csvTime(field("Last Seen")),
+		UpdatedAt:        csvTime(field("Last Updated")),
+		InspectorScore:   csvFloat(field("Inspector Score")),
+		Title:            field("Title"),
+		Severity:         types.Severity(csvValue(field("Severity"))),
+		Status:           types.FindingStatus(csvValue(field("Status"))),
+		Type:             types.FindingType(csvValue(field("Finding Type"))),
+		FixAvailable:     types.FixAvailable(csvValue(field("Fix Available"))),
+		ExploitAvailable: types.ExploitAvailable(csvValue(field("Exploit Available"))),
+	}
+
+	if score := csvFloat(field("Epss Score")); score != nil {
+		finding.Epss = &types.EpssDetails{Score: *score}
+	}
+
+	if resourceId := field("Resource ID"); resourceId != nil {
+		finding.Resources = []types.Resource{{
+			Id:     resourceId,
+			Type:   types.ResourceType(csvValue(field("Resource Type"))),
+			Region: field("Region"),
+		}}
+	}
+
+	if vulnerabilityId := field("Vulnerability Id"); vulnerabilityId != nil {
+		details := &types.PackageVulnerabilityDetails{
+			VulnerabilityId: vulnerabilityId,
+			Source:          field("Vendor"),
+		}
+		// the report includes the CVSS v3 scores of both NVD and the vendor
+		if score := csvFloat(field("NVD CVSS3 Score")); score != nil {
+			details.Cvss = append(details.Cvss, types.CvssScore{
+				BaseScore:     score,
+				ScoringVector: field("NVD CVSS3 Vector"),
+				Source:        aws.String("NVD"),
+				Version:       aws.String("3"),
+			})
+		}
+		if score := csvFloat(field("Vendor CVSS3 Score")); score != nil {
+			details.Cvss = append(details.Cvss, types.CvssScore{
+				BaseScore:     score,
+				ScoringVector: field("Vendor CVSS3 Vector"),
+				Source:        field("Vendor"),
+				Version:       aws.String("3"),
+			})
+		}
+		// the affected packages and their versions are comma separated lists, in the same order
+		names := csvList(field("Affected Packages"))
+		versions := csvList(field("Package Installed Version"))
+		fixedInVersions := csvList(field("Fixed in Version"))
+		for i, name := range names {
+			pkg := types.VulnerablePackage{
+				Name:           &name,
+				PackageManager: types.PackageManager(csvValue(field("Package Manager"))),
+			}
+			if i < len(versions) {
+				pkg.Version = &versions[i]
+			}
+			if i < len(fixedInVersions) {
+				pkg.FixedInVersion = &fixedInVersions[i]
+			}
+			details.VulnerablePackages = append(details.VulnerablePackages, pkg)
+		}
+		finding.PackageVulnerabilityDetails = details
+	}
+
+	return finding
+}
+
+func csvValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func csvList(value *string) []string {
+	if value == nil {
+		return nil
+	}
+	var res []string
+	for _, item := range strings.Split(*value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func csvFloat(value *string) *float64 {
+	if value == nil {
+		return nil
+	}
+	f, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func csvTime(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	if t, ok := parseTimestamp(*value); ok {
+		return &t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, *value); err == nil {
+		return &t
+	}
+	return nil
+}
+
+// newInspectorFinding populates an InspectorFinding from the AWS SDK finding
+func newInspectorFinding(finding types.Finding) *InspectorFinding {
+	row := &InspectorFinding{
+		AwsAccountId:               finding.AwsAccountId,
+		CodeVulnerabilityDetails:   finding.CodeVulnerabilityDetails,
+		Description:                finding.Description,
+		FindingArn:                 finding.FindingArn,
+		FirstObservedAt:            finding.FirstObservedAt,
+		InspectorScore:             finding.InspectorScore,
+		InspectorScoreDetails:      finding.InspectorScoreDetails,
+		LastObservedAt:             finding.LastObservedAt,
+		NetworkReachabilityDetails: finding.NetworkReachabilityDetails,
+		Remediation:                finding.Remediation,
+		Resources:                  finding.Resources,
+		Title:                      finding.Title,
+		UpdatedAt:                  finding.UpdatedAt,
+	}
+
+	row.ExploitAvailable = enumString(finding.ExploitAvailable)
+	row.FixAvailable = enumString(finding.FixAvailable)
+	row.Severity = enumString(finding.Severity)
+	row.Status = enumString(finding.Status)
+	row.Type = enumString(finding.Type)
+
+	if finding.Epss != nil {
+		row.EpssScore = &finding.Epss.Score
+	}
+	if finding.ExploitabilityDetails != nil {
+		row.LastKnownExploitAt = finding.ExploitabilityDetails.LastKnownExploitAt
+	}
+
+	if details := finding.PackageVulnerabilityDetails; details != nil {
+		row.PackageVulnerabilityDetails = details
+		row.RelatedVulnerabilities = details.RelatedVulnerabilities
+		row.VulnerabilityId = details.VulnerabilityId
+		row.VulnerablePackages = details.VulnerablePackages
+		for _, cvss := range details.Cvss {
+			if cvss.BaseScore != nil && (row.CvssScore == nil || *cvss.BaseScore > *row.CvssScore) {
+				row.CvssScore = cvss.BaseScore
+			}
+		}
+	}
+
+	return row
+}
+
+func enumString[T ~string](value T) *string {
+	if value == "" {
+		return nil
+	}
+	s := string(value)
+	return &s
+}
@@ -0,0 +1,113 @@
+package inspector_finding
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const InspectorFindingTableIdentifier = "aws_inspector_finding"
+
+// InspectorFindingTable - table for Amazon Inspector findings
+type InspectorFindingTable struct{}
+
+func (c *InspectorFindingTable) Identifier() string {
+	return InspectorFindingTableIdentifier
+}
+
+func (c *InspectorFindingTable) GetSourceMetadata() ([]*table.SourceMetadata[*InspectorFinding], error) {
+	// findings reports are written to the key prefix chosen for the report, and Finding events are delivered by an EventBridge rule
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer(tables.FirehoseDefaultFileLayout),
+	}
+
+	return []*table.SourceMetadata[*InspectorFinding]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewInspectorFindingExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewInspectorFindingExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *InspectorFindingTable) EnrichRow(row *InspectorFinding, sourceEnrichmentFields schema.SourceEnrichment) (*InspectorFinding, error) {
+	// use the most recent timestamp of the finding, so that each update of a finding is recorded at the time it was made
+	var timestamp *time.Time
+	for _, t := range []*time.Time{row.UpdatedAt, row.LastObservedAt, row.FirstObservedAt} {
+		if t != nil {
+			timestamp = t
+			break
+		}
+	}
+	if timestamp == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"updatedAt"}, nil)
+	}
+
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *timestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = timestamp.Truncate(24 * time.Hour)
+
+	if row.FindingArn != nil {
+		row.TpAkas = append(row.TpAkas, tables.AwsAkasFromArn(*row.FindingArn)...)
+	}
+
+	for _, resource := range row.Resources {
+		// ECR image and Lambda function resource IDs are ARNs, EC2 instance resource IDs are instance IDs
+		var arn string
+		switch {
+		case resource.Id == nil:
+			continue
+		case strings.HasPrefix(*resource.Id, "arn:"):
+			arn = *resource.Id
+		case strings.HasPrefix(*resource.Id, "i-") && resource.Partition != nil && resource.Region != nil && row.AwsAccountId != nil:
+			arn = fmt.Sprintf("arn:%s:ec2:%s:%s:instance/%s", *resource.Partition, *resource.Region, *row.AwsAccountId, *resource.Id)
+		}
+		if arn != "" && !slices.Contains(row.TpAkas, arn) {
+			row.TpAkas = append(row.TpAkas, arn)
+		}
+
+		if resource.Details != nil && resource.Details.AwsEc2Instance != nil {
+			instance := resource.Details.AwsEc2Instance
+			for _, ip := range slices.Concat(instance.IpV4Addresses, instance.IpV6Addresses) {
+				if !slices.Contains(row.TpIps, ip) {
+					row.TpIps = append(row.TpIps, ip)
+				}
+			}
+		}
+	}
+
+	return row, nil
+}
+
+func (c *InspectorFindingTable) GetDescription() string {
+	return "Amazon Inspector findings report software vulnerabilities and unintended network exposure in EC2 instances, ECR container images and Lambda functions. This table provides the vulnerability details, including CVE IDs, CVSS and EPSS scores, vulnerable packages and fixed-in versions, network reachability details and the affected resources, allowing vulnerabilities to be trended over time."
+}
@@ -0,0 +1,62 @@
+package lambda_log
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type LambdaLog struct {
+	schema.CommonFields
+
+	BilledDurationMs   *float64                `json:"billed_duration_ms,omitempty"`
+	DurationMs         *float64                `json:"duration_ms,omitempty"`
+	FunctionName       *string                 `json:"function_name,omitempty"`
+	FunctionVersion    *string                 `json:"function_version,omitempty"`
+	InitDurationMs     *float64                `json:"init_duration_ms,omitempty"`
+	InitializationType *string                 `json:"initialization_type,omitempty"`
+	Level              *string                 `json:"level,omitempty"`
+	LogFormat          *string                 `json:"log_format,omitempty"`
+	MaxMemoryUsedMb    *int                    `json:"max_memory_used_mb,omitempty"`
+	MemorySizeMb       *int                    `json:"memory_size_mb,omitempty"`
+	Message            *string                 `json:"message,omitempty"`
+	Record             *map[string]interface{} `json:"record,omitempty" parquet:"type=JSON"`
+	RequestId          *string                 `json:"request_id,omitempty"`
+	RuntimeVersion     *string                 `json:"runtime_version,omitempty"`
+	RuntimeVersionArn  *string                 `json:"runtime_version_arn,omitempty"`
+	Status             *string                 `json:"status,omitempty"`
+	Timestamp          *time.Time              `json:"timestamp,omitempty"`
+	Type               *string                 `json:"type,omitempty"`
+	XraySampled        *bool                   `json:"xray_sampled,omitempty"`
+	XraySegmentId      *string                 `json:"xray_segment_id,omitempty"`
+	XrayTraceId        *string                 `json:"xray_trace_id,omitempty"`
+}
+
+func (l *LambdaLog) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"billed_duration_ms":  "The amount of time that was billed for the invocation, in milliseconds (REPORT lines only).",
+		"duration_ms":         "The amount of time that the function's handler spent processing the invocation, in milliseconds (REPORT lines only).",
+		"function_name":       "The name of the function, derived from the /aws/lambda/<function name> log group name.",
+		"function_version":    "The version of the function, derived from the log stream name or the START line.",
+		"init_duration_ms":    "For the first invocation served by an execution environment (a cold start), the time taken to run the initialization code, in milliseconds.",
+		"initialization_type": "The initialization type of the execution environment, e.g. on-demand, provisioned-concurrency or snap-start (INIT_START lines only).",
+		"level":               "The log level of an application log entry, e.g. INFO or ERROR.",
+		"log_format":          "The format of the log entry, either Text or JSON.",
+		"max_memory_used_mb":  "The maximum amount of memory used by the invocation, in MB (REPORT lines only).",
+		"memory_size_mb":      "The amount of memory allocated to the function, in MB (REPORT lines only).",
+		"message":             "The log message. For platform events in text format this is the full log line.",
+		"record":              "The full log entry for JSON application logs, or the record of a JSON platform event.",
+		"request_id":          "The ID of the invocation request that the log entry relates to.",
+		"runtime_version":     "The version of the runtime used by the execution environment (INIT_START lines only).",
+		"runtime_version_arn": "The ARN of the runtime version used by the execution environment (INIT_START lines only).",
+		"status":              "The status of the invocation or initialization phase, e.g. success, error or timeout.",
+		"timestamp":           "The date and time of the log entry, in ISO 8601 format and UTC.",
+		"type":                "The type of the log entry, either a platform event type (e.g. platform.start, platform.report) or function for application logs.",
+		"xray_sampled":        "Whether the invocation was sampled by AWS X-Ray.",
+		"xray_segment_id":     "The ID of the AWS X-Ray segment for the invocation.",
+		"xray_trace_id":       "The ID of the AWS X-Ray trace for the invocation.",
+
+		// Override table specific tp_* column descriptions
+		"tp_timestamp": "The date and time of the log entry, in ISO 8601 format and UTC.",
+	}
+}
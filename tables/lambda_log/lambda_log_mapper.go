@@ -0,0 +1,320 @@
+package lambda_log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+)
+
+const (
+	// the type of application (i.e. non-platform) log entries
+	lambdaLogTypeFunction = "function"
+
+	lambdaLogFormatText = "Text"
+	lambdaLogFormatJSON = "JSON"
+)
+
+var (
+	startLineRegex       = regexp.MustCompile(`^START RequestId: (\S+)(?:\s+Version: (\S+))?`)
+	endLineRegex         = regexp.MustCompile(`^END RequestId: (\S+)`)
+	initStartLineRegex   = regexp.MustCompile(`^INIT_START Runtime Version: (\S+)\s+Runtime Version ARN: (\S+)`)
+	applicationLineRegex = regexp.MustCompile(`(?s)^(?:\[([A-Z]+)\]\t)?(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?Z)\t([0-9a-fA-F-]{36})\t(?:(TRACE|DEBUG|INFO|WARN|ERROR|FATAL)\t)?(.*)$`)
+)
+
+// LambdaLogMapper maps a Lambda function log entry, in either the text or JSON log format, to a LambdaLog
+type LambdaLogMapper struct {
+}
+
+func (m *LambdaLogMapper) Identifier() string {
+	return "aws_lambda_log_mapper"
+}
+
+func (m *LambdaLogMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*LambdaLog]) (*LambdaLog, error) {
+	var message string
+	// the ingestion time of the event, used if the log entry does not contain a timestamp
+	var eventTimestamp *time.Time
+
+	switch v := a.(type) {
+	case []byte:
+		message = string(v)
+	case string:
+		message = v
+	case *string:
+		if v == nil {
+			return nil, fmt.Errorf("nil string input")
+		}
+		message = *v
+	case cwTypes.FilteredLogEvent:
+		message = *v.Message
+		if v.Timestamp != nil {
+			t := time.UnixMilli(*v.Timestamp).UTC()
+			eventTimestamp = &t
+		}
+	default:
+		return nil, fmt.Errorf("expected byte[], string, or *string, got %T", a)
+	}
+	message = strings.TrimRight(message, "\r\n")
+
+	log := &LambdaLog{}
+	// application logs in the text format may also be JSON, so fall back to the text format if they cannot be decoded
+	if !strings.HasPrefix(strings.TrimSpace(message), "{") || log.initialiseFromJSON(message) != nil {
+		*log = LambdaLog{}
+		if err := log.initialiseFromText(message); err != nil {
+			return nil, err
+		}
+	}
+
+	if log.Timestamp == nil {
+		log.Timestamp = eventTimestamp
+	}
+	if log.Timestamp == nil {
+		return nil, fmt.Errorf("no timestamp found in log entry")
+	}
+
+	return log, nil
+}
+
+// initialiseFromText parses a log entry in the text log format, which may be a platform line
+// (START, END, REPORT, INIT_START or INIT_REPORT) or an application log line
+func (l *LambdaLog) initialiseFromText(message string) error {
+	l.LogFormat = utils.ToStringPointer(lambdaLogFormatText)
+	l.Message = &message
+
+	switch {
+	case strings.HasPrefix(message, "START "):
+		l.Type = utils.ToStringPointer("platform.start")
+		if match := startLineRegex.FindStringSubmatch(message); match != nil {
+			l.RequestId = &match[1]
+			if match[2] != "" {
+				l.FunctionVersion = &match[2]
+			}
+		}
+	case strings.HasPrefix(message, "END "):
+		l.Type = utils.ToStringPointer("platform.end")
+		if match := endLineRegex.FindStringSubmatch(message); match != nil {
+			l.RequestId = &match[1]
+		}
+	case strings.HasPrefix(message, "REPORT "):
+		l.Type = utils.ToStringPointer("platform.report")
+		return l.initialiseFromPlatformFields(parsePlatformFields(strings.TrimPrefix(message, "REPORT ")))
+	case strings.HasPrefix(message, "INIT_START "):
+		l.Type = utils.ToStringPointer("platform.initStart")
+		if match := initStartLineRegex.FindStringSubmatch(message); match != nil {
+			l.RuntimeVersion = &match[1]
+			l.RuntimeVersionArn = &match[2]
+		}
+	case strings.HasPrefix(message, "INIT_REPORT "):
+		l.Type = utils.ToStringPointer("platform.initReport")
+		return l.initialiseFromPlatformFields(parsePlatformFields(strings.TrimPrefix(message, "INIT_REPORT ")))
+	default:
+		l.Type = utils.ToStringPointer(lambdaLogTypeFunction)
+		// the runtime may prefix application log lines with the timestamp, request ID and level
+		// e.g. "2024-01-01T12:00:00.000Z\t<request id>\tINFO\tmessage" or "[INFO]\t2024-01-01T12:00:00.000Z\t<request id>\tmessage"
+		if match := applicationLineRegex.FindStringSubmatch(message); match != nil {
+			timestamp, err := time.Parse(time.RFC3339Nano, match[2])
+			if err != nil {
+				return fmt.Errorf("error parsing timestamp: %w", err)
+			}
+			l.Timestamp = &timestamp
+			l.RequestId = &match[3]
+			if match[1] != "" {
+				l.Level = &match[1]
+			} else if match[4] != "" {
+				l.Level = &match[4]
+			}
+			l.Message = &match[5]
+		}
+	}
+	return nil
+}
+
+// parsePlatformFields parses the tab separated "<name>: <value>" fields of a REPORT or INIT_REPORT line,
+// e.g. "RequestId: <id>\tDuration: 1.23 ms\tBilled Duration: 2 ms\t...\nXRAY TraceId: <id>\tSegmentId: <id>\tSampled: true"
+func parsePlatformFields(s string) map[string]string {
+	fields := make(map[string]string)
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '\t' || r == '\n' })
+	for _, part := range parts {
+		part = strings.TrimPrefix(strings.TrimSpace(part), "XRAY ")
+		if name, value, ok := strings.Cut(part, ": "); ok {
+			fields[name] = strings.TrimSpace(value)
+		}
+	}
+	return fields
+}
+
+func (l *LambdaLog) initialiseFromPlatformFields(fields map[string]string) error {
+	var err error
+	for name, value := range fields {
+		switch name {
+		case "RequestId":
+			l.RequestId = utils.ToStringPointer(value)
+		case "Duration":
+			l.DurationMs, err = parseQuantity(value)
+		case "Billed Duration":
+			l.BilledDurationMs, err = parseQuantity(value)
+		case "Init Duration":
+			l.InitDurationMs, err = parseQuantity(value)
+		case "Memory Size":
+			l.MemorySizeMb, err = parseIntQuantity(value)
+		case "Max Memory Used":
+			l.MaxMemoryUsedMb, err = parseIntQuantity(value)
+		case "Status":
+			l.Status = utils.ToStringPointer(value)
+		case "TraceId":
+			l.XrayTraceId = utils.ToStringPointer(value)
+		case "SegmentId":
+			l.XraySegmentId = utils.ToStringPointer(value)
+		case "Sampled":
+			sampled := value == "true"
+			l.XraySampled = &sampled
+		}
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// initialiseFromJSON parses a log entry in the JSON log format, which is either a platform event
+// (with a type of platform.*) or an application log entry
+func (l *LambdaLog) initialiseFromJSON(message string) error {
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(message), &entry); err != nil {
+		return err
+	}
+	l.LogFormat = utils.ToStringPointer(lambdaLogFormatJSON)
+
+	if eventType, _ := entry["type"].(string); strings.HasPrefix(eventType, "platform.") {
+		l.Type = &eventType
+		if err := l.setTimestamp(entry["time"]); err != nil {
+			return err
+		}
+		if record, ok := entry["record"].(map[string]interface{}); ok {
+			l.Record = &record
+			l.initialiseFromPlatformRecord(eventType, record)
+		}
+		return nil
+	}
+
+	// application log entry, e.g. {"timestamp": "...", "level": "INFO", "requestId": "...", "message": "..."}
+	l.Type = utils.ToStringPointer(lambdaLogTypeFunction)
+	l.Record = &entry
+	if err := l.setTimestamp(entry["timestamp"]); err != nil {
+		return err
+	}
+	l.Level = stringValue(entry["level"])
+	l.RequestId = stringValue(entry["requestId"])
+	switch v := entry["message"].(type) {
+	case nil:
+	case string:
+		l.Message = &v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		l.Message = utils.ToStringPointer(string(b))
+	}
+	return nil
+}
+
+func (l *LambdaLog) initialiseFromPlatformRecord(eventType string, record map[string]interface{}) {
+	l.RequestId = stringValue(record["requestId"])
+	l.FunctionVersion = stringValue(record["version"])
+	l.Status = stringValue(record["status"])
+	l.RuntimeVersion = stringValue(record["runtimeVersion"])
+	l.RuntimeVersionArn = stringValue(record["runtimeVersionArn"])
+	l.InitializationType = stringValue(record["initializationType"])
+
+	if metrics, ok := record["metrics"].(map[string]interface{}); ok {
+		// the duration of a platform.initReport event is the initialization duration
+		if eventType == "platform.initReport" {
+			l.InitDurationMs = floatValue(metrics["durationMs"])
+		} else {
+			l.DurationMs = floatValue(metrics["durationMs"])
+			l.InitDurationMs = floatValue(metrics["initDurationMs"])
+		}
+		l.BilledDurationMs = floatValue(metrics["billedDurationMs"])
+		if memorySize := floatValue(metrics["memorySizeMB"]); memorySize != nil {
+			l.MemorySizeMb = utils.ToIntegerPointer(int(*memorySize))
+		}
+		if maxMemoryUsed := floatValue(metrics["maxMemoryUsedMB"]); maxMemoryUsed != nil {
+			l.MaxMemoryUsedMb = utils.ToIntegerPointer(int(*maxMemoryUsed))
+		}
+	}
+
+	// the tracing value is an X-Ray trace header, e.g. Root=1-5e1b4151-5ac6c58f5b5daa6532e4f0d5;Parent=1234567890abcdef;Sampled=1
+	if tracing, ok := record["tracing"].(map[string]interface{}); ok {
+		if value, ok := tracing["value"].(string); ok {
+			for _, part := range strings.Split(value, ";") {
+				name, v, _ := strings.Cut(part, "=")
+				switch name {
+				case "Root":
+					l.XrayTraceId = utils.ToStringPointer(v)
+				case "Parent":
+					l.XraySegmentId = utils.ToStringPointer(v)
+				case "Sampled":
+					sampled := v == "1"
+					l.XraySampled = &sampled
+				}
+			}
+		}
+	}
+}
+
+func (l *LambdaLog) setTimestamp(value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return fmt.Errorf("error parsing timestamp: %w", err)
+	}
+	timestamp = timestamp.UTC()
+	l.Timestamp = &timestamp
+	return nil
+}
+
+// parseQuantity parses a value with a unit, e.g. "1.23 ms"
+func parseQuantity(value string) (*float64, error) {
+	number, _, _ := strings.Cut(value, " ")
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// parseIntQuantity parses an integer value with a unit, e.g. "128 MB"
+func parseIntQuantity(value string) (*int, error) {
+	number, _, _ := strings.Cut(value, " ")
+	i, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func stringValue(value interface{}) *string {
+	if s, ok := value.(string); ok && s != "" {
+		return &s
+	}
+	return nil
+}
+
+func floatValue(value interface{}) *float64 {
+	if f, ok := value.(float64); ok {
+		return &f
+	}
+	return nil
+}
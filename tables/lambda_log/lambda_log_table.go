@@ -0,0 +1,66 @@
+package lambda_log
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const LambdaLogTableIdentifier = "aws_lambda_log"
+
+// the default log group of a function is /aws/lambda/<function name>
+const lambdaLogGroupPrefix = "/aws/lambda/"
+
+// log stream names include the function version, e.g. 2024/01/01/[$LATEST]0123456789abcdef0123456789abcdef
+var logStreamVersionRegex = regexp.MustCompile(`^\d{4}/\d{2}/\d{2}/\[([^\]]+)\]`)
+
+// LambdaLogTable - table for Lambda function logs
+type LambdaLogTable struct{}
+
+func (c *LambdaLogTable) Identifier() string {
+	return LambdaLogTableIdentifier
+}
+
+func (c *LambdaLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*LambdaLog], error) {
+	return []*table.SourceMetadata[*LambdaLog]{
+		{
+			// CloudWatch source
+			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
+			Mapper:     &LambdaLogMapper{},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *LambdaLogTable) EnrichRow(row *LambdaLog, sourceEnrichmentFields schema.SourceEnrichment) (*LambdaLog, error) {
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *row.Timestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = row.Timestamp.Truncate(24 * time.Hour)
+
+	// the source name is the log group name, and the source location the log stream name
+	if row.TpSourceName != nil && strings.HasPrefix(*row.TpSourceName, lambdaLogGroupPrefix) {
+		functionName := strings.TrimPrefix(*row.TpSourceName, lambdaLogGroupPrefix)
+		row.FunctionName = &functionName
+	}
+	if row.FunctionVersion == nil && row.TpSourceLocation != nil {
+		if match := logStreamVersionRegex.FindStringSubmatch(*row.TpSourceLocation); match != nil {
+			row.FunctionVersion = &match[1]
+		}
+	}
+
+	return row, nil
+}
+
+func (c *LambdaLogTable) GetDescription() string {
+	return "AWS Lambda function logs contain the output of your functions and the platform events for each invocation. This table provides the application log entries alongside invocation start, end and report details, including durations, memory usage, cold start initialization times and X-Ray trace IDs."
+}
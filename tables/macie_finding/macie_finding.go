@@ -0,0 +1,75 @@
+package macie_finding
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/macie2/types"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type MacieFinding struct {
+	schema.CommonFields
+	tables.SourceFields
+
+	AccountId             *string                      `json:"account_id,omitempty"`
+	Archived              *bool                        `json:"archived,omitempty"`
+	BucketArn             *string                      `json:"bucket_arn,omitempty"`
+	BucketName            *string                      `json:"bucket_name,omitempty"`
+	Category              *string                      `json:"category,omitempty"`
+	ClassificationDetails *types.ClassificationDetails `json:"classification_details,omitempty" parquet:"type=JSON"` // contains []struct
+	Count                 *int64                       `json:"count,omitempty"`
+	CreatedAt             *time.Time                   `json:"created_at,omitempty"`
+	Description           *string                      `json:"description,omitempty"`
+	Id                    *string                      `json:"id,omitempty"`
+	ObjectKey             *string                      `json:"object_key,omitempty"`
+	Partition             *string                      `json:"partition,omitempty"`
+	PolicyDetails         *types.PolicyDetails         `json:"policy_details,omitempty" parquet:"type=JSON"`
+	Region                *string                      `json:"region,omitempty"`
+	ResourcesAffected     *types.ResourcesAffected     `json:"resources_affected,omitempty" parquet:"type=JSON"` // contains []struct
+	Sample                *bool                        `json:"sample,omitempty"`
+	SchemaVersion         *string                      `json:"schema_version,omitempty"`
+	SensitiveData         []types.SensitiveDataItem    `json:"sensitive_data,omitempty" parquet:"type=JSON"`
+	SensitiveDataTypes    []string                     `json:"sensitive_data_types,omitempty"`
+	Severity              *string                      `json:"severity,omitempty"`
+	SeverityScore         *int64                       `json:"severity_score,omitempty"`
+	Title                 *string                      `json:"title,omitempty"`
+	Type                  *string                      `json:"type,omitempty"`
+	UpdatedAt             *time.Time                   `json:"updated_at,omitempty"`
+}
+
+func (f *MacieFinding) GetColumnDescriptions() map[string]string {
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
+		"account_id":             "The AWS account ID that the finding applies to.",
+		"archived":               "Whether the finding is archived (suppressed).",
+		"bucket_arn":             "The Amazon Resource Name (ARN) of the S3 bucket that the finding applies to.",
+		"bucket_name":            "The name of the S3 bucket that the finding applies to.",
+		"category":               "The category of the finding (CLASSIFICATION for sensitive data findings, POLICY for policy findings).",
+		"classification_details": "The details of a sensitive data finding, including the classification job, the origin of the finding and the sensitive data that was detected, with the location of each occurrence.",
+		"count":                  "The total number of occurrences of the finding.",
+		"created_at":             "The date and time that the finding was created.",
+		"description":            "The description of the finding.",
+		"id":                     "The unique identifier for the finding.",
+		"object_key":             "The key of the S3 object that a sensitive data finding applies to.",
+		"partition":              "The AWS partition that the finding applies to (e.g., aws, aws-cn, aws-us-gov).",
+		"policy_details":         "The details of a policy finding, including the action and the actor that caused the finding.",
+		"region":                 "The AWS region that the finding applies to.",
+		"resources_affected":     "The S3 bucket and object that the finding applies to, including their encryption, public access and tags.",
+		"sample":                 "Whether the finding is a sample finding.",
+		"schema_version":         "The version of the schema used for the finding.",
+		"sensitive_data":         "The categories and types of sensitive data that were detected, with the number of occurrences of each.",
+		"sensitive_data_types":   "The types of sensitive data that were detected, e.g. USA_SOCIAL_SECURITY_NUMBER, and the names of the custom data identifiers that detected data.",
+		"severity":               "The qualitative severity of the finding (Low, Medium or High).",
+		"severity_score":         "The numerical severity score of the finding.",
+		"title":                  "The title of the finding.",
+		"type":                   "The type of the finding, e.g. SensitiveData:S3Object/Personal or Policy:IAMUser/S3BucketPublic.",
+		"updated_at":             "The date and time that the finding was last updated.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARNs of the S3 bucket and the classification job associated with the finding.",
+		"tp_ips":       "The IP address of the actor that caused a policy finding.",
+		"tp_source_ip": "The IP address of the actor that caused a policy finding.",
+		"tp_timestamp": "The date and time that the finding was last updated.",
+	})
+}
@@ -0,0 +1,140 @@
+package macie_finding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/macie2/types"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+)
+
+const macieFindingDetailType = "Macie Finding"
+
+// MacieFindingExtractor is an extractor that receives a sequence of JSON serialised Macie findings, as published
+// to S3 by Macie, or Macie Finding EventBridge events, and extracts a MacieFinding record from each of them
+type MacieFindingExtractor struct {
+}
+
+// NewMacieFindingExtractor creates a new MacieFindingExtractor
+func NewMacieFindingExtractor() artifact_source.Extractor {
+	return &MacieFindingExtractor{}
+}
+
+func (c *MacieFindingExtractor) Identifier() string {
+	return "macie_finding_extractor"
+}
+
+// Extract decodes each finding in the artifact data and returns the MacieFinding records,
+// skipping any EventBridge events which are not Macie findings
+func (c *MacieFindingExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	objects, err := tables.DecodeJSONObjects(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []any
+	for _, object := range objects {
+		var event tables.EventBridgeEvent
+		if err := json.Unmarshal(object, &event); err != nil {
+			return nil, fmt.Errorf("error decoding event: %w", err)
+		}
+
+		// the finding is either the detail of an EventBridge event, or the whole object
+		findingJSON := object
+		if len(event.Detail) > 0 {
+			if event.DetailType == nil || *event.DetailType != macieFindingDetailType {
+				continue
+			}
+			findingJSON = event.Detail
+		}
+
+		// Decode JSON into AWS SDK `types.Finding`
+		var finding types.Finding
+		if err := json.Unmarshal(findingJSON, &finding); err != nil {
+			return nil, fmt.Errorf("error decoding finding: %w", err)
+		}
+
+		res = append(res, newMacieFinding(finding))
+	}
+
+	slog.Debug("MacieFindingExtractor", "record count", len(res))
+	return res, nil
+}
+
+// newMacieFinding populates a MacieFinding from the AWS SDK finding
+func newMacieFinding(finding types.Finding) *MacieFinding {
+	row := &MacieFinding{
+		AccountId:             finding.AccountId,
+		Archived:              finding.Archived,
+		ClassificationDetails: finding.ClassificationDetails,
+		Count:                 finding.Count,
+		CreatedAt:             finding.CreatedAt,
+		Description:           finding.Description,
+		Id:                    finding.Id,
+		Partition:             finding.Partition,
+		PolicyDetails:         finding.PolicyDetails,
+		Region:                finding.Region,
+		ResourcesAffected:     finding.ResourcesAffected,
+		Sample:                finding.Sample,
+		SchemaVersion:         finding.SchemaVersion,
+		Title:                 finding.Title,
+		UpdatedAt:             finding.UpdatedAt,
+	}
+
+	if finding.Category != "" {
+		row.Category = (*string)(&finding.Category)
+	}
+	if finding.Type != "" {
+		row.Type = (*string)(&finding.Type)
+	}
+	if finding.Severity != nil {
+		if finding.Severity.Description != "" {
+			row.Severity = (*string)(&finding.Severity.Description)
+		}
+		row.SeverityScore = finding.Severity.Score
+	}
+
+	if resources := finding.ResourcesAffected; resources != nil {
+		if resources.S3Bucket != nil {
+			row.BucketArn = resources.S3Bucket.Arn
+			row.BucketName = resources.S3Bucket.Name
+		}
+		if resources.S3Object != nil {
+			row.ObjectKey = resources.S3Object.Key
+			if row.BucketArn == nil {
+				row.BucketArn = resources.S3Object.BucketArn
+			}
+		}
+	}
+
+	// sensitive data types, from both the managed and the custom data identifiers
+	if details := finding.ClassificationDetails; details != nil && details.Result != nil {
+		row.SensitiveData = details.Result.SensitiveData
+		for _, item := range details.Result.SensitiveData {
+			for _, detection := range item.Detections {
+				if detection.Type != nil && !slices.Contains(row.SensitiveDataTypes, *detection.Type) {
+					row.SensitiveDataTypes = append(row.SensitiveDataTypes, *detection.Type)
+				}
+			}
+		}
+		if custom := details.Result.CustomDataIdentifiers; custom != nil {
+			for _, detection := range custom.Detections {
+				if detection.Name != nil && !slices.Contains(row.SensitiveDataTypes, *detection.Name) {
+					row.SensitiveDataTypes = append(row.SensitiveDataTypes, *detection.Name)
+				}
+			}
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,93 @@
+package macie_finding
+
+import (
+	"slices"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const MacieFindingTableIdentifier = "aws_macie_finding"
+
+// MacieFindingTable - table for Amazon Macie findings
+type MacieFindingTable struct{}
+
+func (c *MacieFindingTable) Identifier() string {
+	return MacieFindingTableIdentifier
+}
+
+func (c *MacieFindingTable) GetSourceMetadata() ([]*table.SourceMetadata[*MacieFinding], error) {
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("AWSLogs/(%{DATA:org_id}/)?%{NUMBER:account_id}/Macie/%{DATA:region}/%{DATA}/%{DATA}.jsonl.gz"),
+	}
+
+	return []*table.SourceMetadata[*MacieFinding]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewMacieFindingExtractor()),
+			},
+		},
+		{
+			// any other artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewMacieFindingExtractor()),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *MacieFindingTable) EnrichRow(row *MacieFinding, sourceEnrichmentFields schema.SourceEnrichment) (*MacieFinding, error) {
+	// use the time the finding was last updated, so that each update of a finding is recorded at the time it was made
+	timestamp := row.UpdatedAt
+	if timestamp == nil {
+		timestamp = row.CreatedAt
+	}
+	if timestamp == nil {
+		return nil, error_types.NewRowErrorWithFields([]string{"updatedAt"}, nil)
+	}
+
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *timestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = timestamp.Truncate(24 * time.Hour)
+
+	if row.BucketArn != nil {
+		row.TpAkas = append(row.TpAkas, *row.BucketArn)
+	}
+	if row.ClassificationDetails != nil && row.ClassificationDetails.JobArn != nil && !slices.Contains(row.TpAkas, *row.ClassificationDetails.JobArn) {
+		row.TpAkas = append(row.TpAkas, *row.ClassificationDetails.JobArn)
+	}
+
+	// the IP address of the actor that caused a policy finding
+	if row.PolicyDetails != nil && row.PolicyDetails.Actor != nil && row.PolicyDetails.Actor.IpAddressDetails != nil {
+		if ip := row.PolicyDetails.Actor.IpAddressDetails.IpAddressV4; ip != nil {
+			row.TpSourceIP = ip
+			row.TpIps = append(row.TpIps, *ip)
+		}
+	}
+
+	return row, nil
+}
+
+func (c *MacieFindingTable) GetDescription() string {
+	return "Amazon Macie findings report sensitive data discovered in S3 objects and potential policy violations for S3 buckets. This table provides the bucket and object affected, the types and occurrences of sensitive data detected, the policy details and the severity of each finding, allowing the sensitive data held in each bucket to be reported on over time."
+}
@@ -0,0 +1,155 @@
+package network_firewall_log
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+// Alert contains the details of the stateful rule that generated an alert event
+type Alert struct {
+	Action      *string `json:"action,omitempty"`
+	Category    *string `json:"category,omitempty"`
+	Gid         *int64  `json:"gid,omitempty"`
+	Rev         *int64  `json:"rev,omitempty"`
+	Severity    *int32  `json:"severity,omitempty"`
+	Signature   *string `json:"signature,omitempty"`
+	SignatureId *int64  `json:"signature_id,omitempty"`
+}
+
+// Netflow contains the packet and byte counts of a flow event
+type Netflow struct {
+	Age    *int64     `json:"age,omitempty"`
+	Bytes  *int64     `json:"bytes,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+	MaxTtl *int32     `json:"max_ttl,omitempty"`
+	MinTtl *int32     `json:"min_ttl,omitempty"`
+	Pkts   *int64     `json:"pkts,omitempty"`
+	Start  *time.Time `json:"start,omitempty"`
+}
+
+// Tcp contains the TCP flags seen over the lifetime of a flow
+type Tcp struct {
+	Ack      *bool   `json:"ack,omitempty"`
+	Cwr      *bool   `json:"cwr,omitempty"`
+	Ecn      *bool   `json:"ecn,omitempty"`
+	Fin      *bool   `json:"fin,omitempty"`
+	Psh      *bool   `json:"psh,omitempty"`
+	Rst      *bool   `json:"rst,omitempty"`
+	State    *string `json:"state,omitempty"`
+	Syn      *bool   `json:"syn,omitempty"`
+	TcpFlags *string `json:"tcp_flags,omitempty"`
+	Urg      *bool   `json:"urg,omitempty"`
+}
+
+// Tls contains the details of the TLS handshake, when the application protocol is TLS
+type Tls struct {
+	Fingerprint *string `json:"fingerprint,omitempty"`
+	Issuerdn    *string `json:"issuerdn,omitempty"`
+	Notafter    *string `json:"notafter,omitempty"`
+	Notbefore   *string `json:"notbefore,omitempty"`
+	Serial      *string `json:"serial,omitempty"`
+	Sni         *string `json:"sni,omitempty"`
+	Subject     *string `json:"subject,omitempty"`
+	Version     *string `json:"version,omitempty"`
+}
+
+// Http contains the details of the HTTP request, when the application protocol is HTTP
+type Http struct {
+	Hostname        *string `json:"hostname,omitempty"`
+	HttpContentType *string `json:"http_content_type,omitempty"`
+	HttpMethod      *string `json:"http_method,omitempty"`
+	HttpUserAgent   *string `json:"http_user_agent,omitempty"`
+	Length          *int64  `json:"length,omitempty"`
+	Protocol        *string `json:"protocol,omitempty"`
+	Status          *int32  `json:"status,omitempty"`
+	Url             *string `json:"url,omitempty"`
+}
+
+// TlsError contains the error encountered during TLS inspection
+type TlsError struct {
+	ErrorMessage *string `json:"error_message,omitempty"`
+}
+
+// RevocationCheck contains the result of the certificate revocation check performed during TLS inspection
+type RevocationCheck struct {
+	Action      *string `json:"action,omitempty"`
+	LeafCertFpr *string `json:"leaf_cert_fpr,omitempty"`
+	Status      *string `json:"status,omitempty"`
+}
+
+// Verdict contains the final action taken by the firewall for the packet that generated an alert event
+type Verdict struct {
+	Action *string `json:"action,omitempty"`
+}
+
+// NetworkFirewallLog is a single Network Firewall log entry. The Suricata EVE JSON event is flattened
+// into the top level columns, alongside the firewall name, availability zone and event timestamp.
+type NetworkFirewallLog struct {
+	schema.CommonFields
+	tables.SourceFields
+
+	Alert            *Alert           `json:"alert,omitempty"`
+	AppProto         *string          `json:"app_proto,omitempty"`
+	AvailabilityZone *string          `json:"availability_zone,omitempty"`
+	DestIp           *string          `json:"dest_ip,omitempty"`
+	DestPort         *int32           `json:"dest_port,omitempty"`
+	Direction        *string          `json:"direction,omitempty"`
+	EventTimestamp   *time.Time       `json:"event_timestamp,omitempty"`
+	EventType        *string          `json:"event_type,omitempty"`
+	FirewallName     *string          `json:"firewall_name,omitempty"`
+	FlowId           *int64           `json:"flow_id,omitempty"`
+	Http             *Http            `json:"http,omitempty"`
+	IcmpCode         *int32           `json:"icmp_code,omitempty"`
+	IcmpType         *int32           `json:"icmp_type,omitempty"`
+	LogType          *string          `json:"log_type,omitempty"`
+	Netflow          *Netflow         `json:"netflow,omitempty"`
+	Proto            *string          `json:"proto,omitempty"`
+	RevocationCheck  *RevocationCheck `json:"revocation_check,omitempty"`
+	SrcIp            *string          `json:"src_ip,omitempty"`
+	SrcPort          *int32           `json:"src_port,omitempty"`
+	Tcp              *Tcp             `json:"tcp,omitempty"`
+	Timestamp        *time.Time       `json:"timestamp,omitempty"`
+	Tls              *Tls             `json:"tls,omitempty"`
+	TlsError         *TlsError        `json:"tls_error,omitempty"`
+	TlsInspected     *bool            `json:"tls_inspected,omitempty"`
+	Verdict          *Verdict         `json:"verdict,omitempty"`
+}
+
+func (l *NetworkFirewallLog) GetColumnDescriptions() map[string]string {
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
+		"alert":             "The details of the stateful rule that matched the traffic, including the action, signature ID, signature and severity. Only present for alert events.",
+		"app_proto":         "The application layer protocol detected for the flow, e.g. tls, http or dns.",
+		"availability_zone": "The Availability Zone of the firewall endpoint that generated the log entry.",
+		"dest_ip":           "The destination IP address of the traffic.",
+		"dest_port":         "The destination port of the traffic.",
+		"direction":         "The direction of the traffic relative to the flow, e.g. to_server or to_client.",
+		"event_timestamp":   "The date and time that the log entry was created, in UTC.",
+		"event_type":        "The type of the Suricata event (alert, netflow or tls).",
+		"firewall_name":     "The name of the firewall that generated the log entry.",
+		"flow_id":           "The ID of the flow that the event belongs to, which can be used to correlate alert, flow and TLS events.",
+		"http":              "The details of the HTTP request, including the host name, URL, method and user agent, when the application protocol is HTTP.",
+		"icmp_code":         "The ICMP code of the traffic, for ICMP traffic.",
+		"icmp_type":         "The ICMP type of the traffic, for ICMP traffic.",
+		"log_type":          "The type of the log (alert, flow or tls).",
+		"netflow":           "The packet and byte counts, start and end times and TTLs of the flow. Only present for flow events.",
+		"proto":             "The IP protocol of the traffic, e.g. TCP, UDP or ICMP.",
+		"revocation_check":  "The result of the certificate revocation check performed during TLS inspection.",
+		"src_ip":            "The source IP address of the traffic.",
+		"src_port":          "The source port of the traffic.",
+		"tcp":               "The TCP flags seen over the lifetime of the flow.",
+		"timestamp":         "The date and time of the Suricata event, in UTC.",
+		"tls":               "The details of the TLS handshake, including the server name indication (SNI) and certificate, when the application protocol is TLS.",
+		"tls_error":         "The error encountered during TLS inspection. Only present for TLS events.",
+		"tls_inspected":     "Whether the traffic was decrypted by TLS inspection.",
+		"verdict":           "The final action taken by the firewall for the packet that generated the alert.",
+
+		// Override table specific tp_* column descriptions
+		"tp_destination_ip": "The destination IP address of the traffic.",
+		"tp_domains":        "The domains related to the traffic, including the TLS server name indication (SNI) and the HTTP host name.",
+		"tp_ips":            "The IP addresses related to the traffic, including the source and destination IP addresses.",
+		"tp_source_ip":      "The source IP address of the traffic.",
+		"tp_timestamp":      "The date and time of the Suricata event, in UTC.",
+	})
+}
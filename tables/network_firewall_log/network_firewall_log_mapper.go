@@ -0,0 +1,183 @@
+package network_firewall_log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+)
+
+// eveTimestampLayout is the layout of the timestamps in Suricata EVE events, e.g. 2020-10-13T22:10:01.006481+0000
+const eveTimestampLayout = "2006-01-02T15:04:05.999999-0700"
+
+type NetworkFirewallLogMapper struct {
+}
+
+func (m *NetworkFirewallLogMapper) Identifier() string {
+	return "aws_network_firewall_log_mapper"
+}
+
+func (m *NetworkFirewallLogMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*NetworkFirewallLog]) (*NetworkFirewallLog, error) {
+	var jsonBytes []byte
+
+	switch v := a.(type) {
+	case []byte:
+		jsonBytes = v
+	case string:
+		jsonBytes = []byte(v)
+	case *string:
+		jsonBytes = []byte(*v)
+	case cwTypes.FilteredLogEvent:
+		jsonBytes = []byte(*v.Message)
+	default:
+		return nil, fmt.Errorf("expected byte[] or string, got %T", a)
+	}
+
+	var log NetworkFirewallLog
+	if err := unmarshalNetworkFirewallLog(jsonBytes, &log); err != nil {
+		return nil, fmt.Errorf("error decoding JSON: %w", err)
+	}
+
+	return &log, nil
+}
+
+func unmarshalNetworkFirewallLog(data []byte, log *NetworkFirewallLog) error {
+	// timestamps are not in RFC 3339 format and the event timestamp is a string of epoch seconds,
+	// so decode into a temporary struct and convert
+	var temp struct {
+		AvailabilityZone *string `json:"availability_zone"`
+		EventTimestamp   *string `json:"event_timestamp"`
+		FirewallName     *string `json:"firewall_name"`
+		Event            *struct {
+			Alert           *Alert           `json:"alert"`
+			AppProto        *string          `json:"app_proto"`
+			DestIp          *string          `json:"dest_ip"`
+			DestPort        *int32           `json:"dest_port"`
+			Direction       *string          `json:"direction"`
+			EventType       *string          `json:"event_type"`
+			FlowId          *int64           `json:"flow_id"`
+			Http            *Http            `json:"http"`
+			IcmpCode        *int32           `json:"icmp_code"`
+			IcmpType        *int32           `json:"icmp_type"`
+			Proto           *string          `json:"proto"`
+			RevocationCheck *RevocationCheck `json:"revocation_check"`
+			SrcIp           *string          `json:"src_ip"`
+			SrcPort         *int32           `json:"src_port"`
+			Tcp             *Tcp             `json:"tcp"`
+			Timestamp       *string          `json:"timestamp"`
+			Tls             *Tls             `json:"tls"`
+			TlsError        *TlsError        `json:"tls_error"`
+			TlsInspected    *bool            `json:"tls_inspected"`
+			Verdict         *Verdict         `json:"verdict"`
+			Netflow         *struct {
+				Age    *int64  `json:"age"`
+				Bytes  *int64  `json:"bytes"`
+				End    *string `json:"end"`
+				MaxTtl *int32  `json:"max_ttl"`
+				MinTtl *int32  `json:"min_ttl"`
+				Pkts   *int64  `json:"pkts"`
+				Start  *string `json:"start"`
+			} `json:"netflow"`
+		} `json:"event"`
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	if temp.Event == nil {
+		return fmt.Errorf("missing event")
+	}
+
+	log.AvailabilityZone = temp.AvailabilityZone
+	log.FirewallName = temp.FirewallName
+
+	event := temp.Event
+	log.Alert = event.Alert
+	log.AppProto = event.AppProto
+	log.DestIp = event.DestIp
+	log.DestPort = event.DestPort
+	log.Direction = event.Direction
+	log.EventType = event.EventType
+	log.FlowId = event.FlowId
+	log.Http = event.Http
+	log.IcmpCode = event.IcmpCode
+	log.IcmpType = event.IcmpType
+	log.Proto = event.Proto
+	log.RevocationCheck = event.RevocationCheck
+	log.SrcIp = event.SrcIp
+	log.SrcPort = event.SrcPort
+	log.Tcp = event.Tcp
+	log.Tls = event.Tls
+	log.TlsError = event.TlsError
+	log.TlsInspected = event.TlsInspected
+	log.Verdict = event.Verdict
+	log.LogType = logTypeFromEventType(event.EventType)
+
+	var err error
+	if temp.EventTimestamp != nil && *temp.EventTimestamp != "" {
+		seconds, err := strconv.ParseInt(*temp.EventTimestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing event_timestamp: %w", err)
+		}
+		eventTimestamp := time.Unix(seconds, 0).UTC()
+		log.EventTimestamp = &eventTimestamp
+	}
+	if log.Timestamp, err = parseEveTimestamp(event.Timestamp); err != nil {
+		return fmt.Errorf("error parsing event.timestamp: %w", err)
+	}
+	if log.Timestamp == nil && log.EventTimestamp == nil {
+		return fmt.Errorf("missing event_timestamp and event.timestamp")
+	}
+
+	if event.Netflow != nil {
+		log.Netflow = &Netflow{
+			Age:    event.Netflow.Age,
+			Bytes:  event.Netflow.Bytes,
+			MaxTtl: event.Netflow.MaxTtl,
+			MinTtl: event.Netflow.MinTtl,
+			Pkts:   event.Netflow.Pkts,
+		}
+		if log.Netflow.Start, err = parseEveTimestamp(event.Netflow.Start); err != nil {
+			return fmt.Errorf("error parsing event.netflow.start: %w", err)
+		}
+		if log.Netflow.End, err = parseEveTimestamp(event.Netflow.End); err != nil {
+			return fmt.Errorf("error parsing event.netflow.end: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// parseEveTimestamp parses a Suricata EVE timestamp, falling back to RFC 3339
+func parseEveTimestamp(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(eveTimestampLayout, *value)
+	if err != nil {
+		var rfcErr error
+		if t, rfcErr = time.Parse(time.RFC3339Nano, *value); rfcErr != nil {
+			return nil, err
+		}
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// logTypeFromEventType returns the Network Firewall log type for a Suricata event type,
+// i.e. netflow events are written to the flow log and alert and tls events to the log of the same name
+func logTypeFromEventType(eventType *string) *string {
+	if eventType == nil {
+		return nil
+	}
+	logType := *eventType
+	if logType == "netflow" {
+		logType = "flow"
+	}
+	return &logType
+}
@@ -0,0 +1,95 @@
+package network_firewall_log
+
+import (
+	"slices"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const NetworkFirewallLogTableIdentifier = "aws_network_firewall_log"
+
+// NetworkFirewallLogTable - table for Network Firewall alert, flow and TLS logs
+type NetworkFirewallLogTable struct{}
+
+func (c *NetworkFirewallLogTable) Identifier() string {
+	return NetworkFirewallLogTableIdentifier
+}
+
+func (c *NetworkFirewallLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*NetworkFirewallLog], error) {
+	// the default file layout for Network Firewall logs in S3 (alert, flow and tls logs share the same layout)
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("AWSLogs/(%{DATA:org_id}/)?%{NUMBER:account_id}/network-firewall/%{DATA:log_type}/%{DATA:region}/%{DATA:firewall_name}/%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/%{HOUR:hour}/%{DATA}.log.gz"),
+	}
+
+	return []*table.SourceMetadata[*NetworkFirewallLog]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Mapper:     &NetworkFirewallLogMapper{},
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithRowPerLine(),
+			},
+		},
+		{
+			// any artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Mapper:     &NetworkFirewallLogMapper{},
+			Options:    []row_source.RowSourceOption{artifact_source.WithRowPerLine()},
+		},
+		{
+			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
+			Mapper:     &NetworkFirewallLogMapper{},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *NetworkFirewallLogTable) EnrichRow(row *NetworkFirewallLog, sourceEnrichmentFields schema.SourceEnrichment) (*NetworkFirewallLog, error) {
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpIngestTimestamp = time.Now()
+	// the mapper ensures at least one of the timestamps is set, prefer the more precise event timestamp
+	if row.Timestamp != nil {
+		row.TpTimestamp = *row.Timestamp
+	} else {
+		row.TpTimestamp = *row.EventTimestamp
+	}
+	row.TpDate = row.TpTimestamp.Truncate(24 * time.Hour)
+
+	if row.SrcIp != nil {
+		row.TpSourceIP = row.SrcIp
+		row.TpIps = append(row.TpIps, *row.SrcIp)
+	}
+	if row.DestIp != nil {
+		row.TpDestinationIP = row.DestIp
+		row.TpIps = append(row.TpIps, *row.DestIp)
+	}
+
+	if row.Tls != nil && row.Tls.Sni != nil && *row.Tls.Sni != "" {
+		row.TpDomains = append(row.TpDomains, *row.Tls.Sni)
+	}
+	if row.Http != nil && row.Http.Hostname != nil && *row.Http.Hostname != "" && !slices.Contains(row.TpDomains, *row.Http.Hostname) {
+		row.TpDomains = append(row.TpDomains, *row.Http.Hostname)
+	}
+
+	return row, nil
+}
+
+func (c *NetworkFirewallLogTable) GetDescription() string {
+	return "AWS Network Firewall logs record the traffic inspected by a firewall, including the alerts raised by stateful rules, the flows passing through the firewall and the outcome of TLS inspection."
+}
@@ -6,11 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
 type NlbAccessLog struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	ALPNBEProtocol            string    `json:"alpn_be_protocol,omitempty"`
 	ALPNClientPreferenceList  []string  `json:"alpn_client_preference_list,omitempty"`
@@ -127,7 +129,7 @@ func (l *NlbAccessLog) InitialiseFromMap(m map[string]string) error {
 }
 
 func (l *NlbAccessLog) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"alpn_be_protocol":             "The application protocol negotiated with the target. Possible values: h2, http/1.1, http/1.0.",
 		"alpn_client_preference_list":  "The value of the application_layer_protocol_negotiation extension in the client hello message, URL-encoded.",
 		"alpn_fe_protocol":             "The application protocol negotiated with the client. Possible values: h2, http/1.1, http/1.0.",
@@ -158,5 +160,5 @@ func (l *NlbAccessLog) GetColumnDescriptions() map[string]string {
 		"tp_index":     "The resource ID of the load balancer handling the request.",
 		"tp_ips":       "All IP addresses associated with the request, including the client IP and destination IP.",
 		"tp_source_ip": "The IP address of the client initiating the connection.",
-	}
+	})
 }
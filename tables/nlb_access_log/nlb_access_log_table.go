@@ -52,6 +52,7 @@ func (c *NlbAccessLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*NlbAcc
 
 func (c *NlbAccessLogTable) EnrichRow(row *NlbAccessLog, sourceEnrichmentFields schema.SourceEnrichment) (*NlbAccessLog, error) {
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	row.TpID = xid.New().String()
 	row.TpIngestTimestamp = time.Now()
@@ -0,0 +1,44 @@
+package route53_public_dns_query_log
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+type Route53PublicDnsQueryLog struct {
+	schema.CommonFields
+
+	EdgeLocation     *string    `json:"edge_location,omitempty"`
+	EdnsClientSubnet *string    `json:"edns_client_subnet,omitempty"`
+	HostedZoneId     *string    `json:"hosted_zone_id,omitempty"`
+	Protocol         *string    `json:"protocol,omitempty"`
+	QueryName        *string    `json:"query_name,omitempty"`
+	QueryType        *string    `json:"query_type,omitempty"`
+	ResolverIp       *string    `json:"resolver_ip,omitempty"`
+	ResponseCode     *string    `json:"response_code,omitempty"`
+	Timestamp        *time.Time `json:"timestamp,omitempty"`
+	Version          *string    `json:"version,omitempty"`
+}
+
+func (l *Route53PublicDnsQueryLog) GetColumnDescriptions() map[string]string {
+	return map[string]string{
+		"edge_location":      "The Route 53 edge location that responded to the query, identified by a three-letter code and an arbitrarily assigned number.",
+		"edns_client_subnet": "A partial IP address for the client that the request originated from, if the DNS resolver supports EDNS0 client subnet.",
+		"hosted_zone_id":     "The ID of the public hosted zone that is associated with all the DNS queries in the log.",
+		"protocol":           "The protocol that was used to submit the query, either TCP or UDP.",
+		"query_name":         "The domain or subdomain that was specified in the request.",
+		"query_type":         "The DNS record type that was specified in the request, or ANY.",
+		"resolver_ip":        "The IP address of the DNS resolver that submitted the request to Route 53.",
+		"response_code":      "The DNS response code that Route 53 returned in response to the DNS query, e.g. NOERROR or NXDOMAIN.",
+		"timestamp":          "The date and time that Route 53 responded to the request, in ISO 8601 format and UTC.",
+		"version":            "The version number of the query log format.",
+
+		// Override table specific tp_* column descriptions
+		"tp_akas":      "The ARN of the hosted zone that received the query.",
+		"tp_domains":   "The domain or subdomain that was specified in the query.",
+		"tp_ips":       "The IP address of the DNS resolver that submitted the query.",
+		"tp_source_ip": "The IP address of the DNS resolver that submitted the query.",
+		"tp_timestamp": "The date and time that Route 53 responded to the request, in ISO 8601 format and UTC.",
+	}
+}
@@ -0,0 +1,75 @@
+package route53_public_dns_query_log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+)
+
+// the number of space-delimited fields in a public DNS query log entry
+const publicDnsQueryLogFieldCount = 10
+
+// Route53PublicDnsQueryLogMapper maps a space-delimited Route 53 public DNS query log entry,
+// either read from a CloudWatch log group or from an exported log file, to a Route53PublicDnsQueryLog
+type Route53PublicDnsQueryLogMapper struct {
+}
+
+func (m *Route53PublicDnsQueryLogMapper) Identifier() string {
+	return "aws_route53_public_dns_query_log_mapper"
+}
+
+func (m *Route53PublicDnsQueryLogMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*Route53PublicDnsQueryLog]) (*Route53PublicDnsQueryLog, error) {
+	var line string
+
+	switch v := a.(type) {
+	case []byte:
+		line = string(v)
+	case string:
+		line = v
+	case *string:
+		if v == nil {
+			return nil, fmt.Errorf("nil string input")
+		}
+		line = *v
+	case cwTypes.FilteredLogEvent:
+		line = *v.Message
+	default:
+		return nil, fmt.Errorf("expected byte[], string, or *string, got %T", a)
+	}
+
+	fields := strings.Fields(line)
+	// log files exported from CloudWatch Logs prefix each entry with the ingestion timestamp
+	if len(fields) == publicDnsQueryLogFieldCount+1 {
+		if _, err := time.Parse(time.RFC3339, fields[0]); err == nil {
+			fields = fields[1:]
+		}
+	}
+	if len(fields) != publicDnsQueryLogFieldCount {
+		return nil, fmt.Errorf("expected %d fields, got %d", publicDnsQueryLogFieldCount, len(fields))
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing timestamp: %w", err)
+	}
+	timestamp = timestamp.UTC()
+
+	return &Route53PublicDnsQueryLog{
+		Version:          &fields[0],
+		Timestamp:        &timestamp,
+		HostedZoneId:     &fields[2],
+		QueryName:        &fields[3],
+		QueryType:        &fields[4],
+		ResponseCode:     &fields[5],
+		Protocol:         &fields[6],
+		EdgeLocation:     &fields[7],
+		ResolverIp:       tables.NilIfDash(&fields[8]),
+		EdnsClientSubnet: tables.NilIfDash(&fields[9]),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package route53_public_dns_query_log
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const Route53PublicDnsQueryLogTableIdentifier = "aws_route53_public_dns_query_log"
+
+// Route53PublicDnsQueryLogTable - table for Route 53 public hosted zone query logs
+type Route53PublicDnsQueryLogTable struct{}
+
+func (c *Route53PublicDnsQueryLogTable) Identifier() string {
+	return Route53PublicDnsQueryLogTableIdentifier
+}
+
+func (c *Route53PublicDnsQueryLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*Route53PublicDnsQueryLog], error) {
+	// public DNS query logs are only delivered to CloudWatch Logs (in us-east-1),
+	// log files exported from the log group can be collected using any artifact source
+	return []*table.SourceMetadata[*Route53PublicDnsQueryLog]{
+		{
+			// CloudWatch source
+			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
+			Mapper:     &Route53PublicDnsQueryLogMapper{},
+		},
+		{
+			// any artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Mapper:     &Route53PublicDnsQueryLogMapper{},
+			Options:    []row_source.RowSourceOption{artifact_source.WithRowPerLine()},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *Route53PublicDnsQueryLogTable) EnrichRow(row *Route53PublicDnsQueryLog, sourceEnrichmentFields schema.SourceEnrichment) (*Route53PublicDnsQueryLog, error) {
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *row.Timestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = row.Timestamp.Truncate(24 * time.Hour)
+
+	if row.QueryName != nil {
+		if domain := strings.TrimSuffix(*row.QueryName, "."); domain != "" {
+			row.TpDomains = append(row.TpDomains, domain)
+		}
+	}
+
+	if row.ResolverIp != nil {
+		row.TpSourceIP = row.ResolverIp
+		row.TpIps = append(row.TpIps, *row.ResolverIp)
+	}
+
+	if row.HostedZoneId != nil {
+		row.TpAkas = append(row.TpAkas, "arn:aws:route53:::hostedzone/"+*row.HostedZoneId)
+	}
+
+	return row, nil
+}
+
+func (c *Route53PublicDnsQueryLogTable) GetDescription() string {
+	return "AWS Route 53 public DNS query logs record the DNS queries that Route 53 receives for public hosted zones, including the domain and record type requested, the response code, the edge location that responded and the resolver that submitted the query."
+}
@@ -0,0 +1,73 @@
+package route53_resolver_query_log
+
+import (
+	"time"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+// Answer is a single answer returned by the Resolver in response to a query
+type Answer struct {
+	Rdata *string `json:"rdata,omitempty"`
+	Type  *string `json:"type,omitempty"`
+	Class *string `json:"class,omitempty"`
+}
+
+// SrcIds identifies the source of the query
+type SrcIds struct {
+	Instance                 *string `json:"instance,omitempty"`
+	ResolverEndpoint         *string `json:"resolver_endpoint,omitempty"`
+	ResolverNetworkInterface *string `json:"resolver_network_interface,omitempty"`
+}
+
+type Route53ResolverQueryLog struct {
+	schema.CommonFields
+	tables.SourceFields
+
+	AccountId            *string    `json:"account_id,omitempty"`
+	Answers              []Answer   `json:"answers,omitempty" parquet:"type=JSON"`
+	FirewallDomainListId *string    `json:"firewall_domain_list_id,omitempty"`
+	FirewallRuleAction   *string    `json:"firewall_rule_action,omitempty"`
+	FirewallRuleGroupId  *string    `json:"firewall_rule_group_id,omitempty"`
+	QueryClass           *string    `json:"query_class,omitempty"`
+	QueryName            *string    `json:"query_name,omitempty"`
+	QueryTimestamp       *time.Time `json:"query_timestamp,omitempty"`
+	QueryType            *string    `json:"query_type,omitempty"`
+	Rcode                *string    `json:"rcode,omitempty"`
+	Region               *string    `json:"region,omitempty"`
+	SrcAddr              *string    `json:"srcaddr,omitempty"`
+	SrcIds               *SrcIds    `json:"srcids,omitempty"`
+	SrcPort              *int32     `json:"srcport,omitempty"`
+	Transport            *string    `json:"transport,omitempty"`
+	Version              *string    `json:"version,omitempty"`
+	VpcId                *string    `json:"vpc_id,omitempty"`
+}
+
+func (l *Route53ResolverQueryLog) GetColumnDescriptions() map[string]string {
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
+		"account_id":              "The ID of the AWS account that created the VPC.",
+		"answers":                 "The answers returned by the Resolver, each with the answer data (rdata), record type and class.",
+		"firewall_domain_list_id": "The ID of the DNS Firewall domain list that the query name matched, if a DNS Firewall rule matched the query.",
+		"firewall_rule_action":    "The action specified by the DNS Firewall rule that matched the query (ALERT, BLOCK or ALLOW).",
+		"firewall_rule_group_id":  "The ID of the DNS Firewall rule group that matched the query.",
+		"query_class":             "The class of the query, e.g. IN.",
+		"query_name":              "The domain name (example.com) or subdomain name (www.example.com) that was specified in the query.",
+		"query_timestamp":         "The date and time that the query was submitted, in ISO 8601 format and UTC.",
+		"query_type":              "The DNS record type that was specified in the request, or ANY.",
+		"rcode":                   "The DNS response code that the Resolver returned in response to the query, e.g. NOERROR or NXDOMAIN.",
+		"region":                  "The AWS region where the VPC was created.",
+		"srcaddr":                 "The IP address of the instance that the query originated from.",
+		"srcids":                  "The IDs of the instance, the Resolver endpoint and the Resolver network interface that the query originated from or passed through.",
+		"srcport":                 "The port on the instance that the query originated from.",
+		"transport":               "The protocol used to submit the DNS query (UDP or TCP).",
+		"version":                 "The version number of the query log format.",
+		"vpc_id":                  "The ID of the VPC that the query originated in.",
+
+		// Override table specific tp_* column descriptions
+		"tp_domains":   "The domain name that was specified in the query.",
+		"tp_ips":       "The IP addresses related to the query, including the source address and any IP addresses returned in the answers.",
+		"tp_source_ip": "The IP address of the instance that the query originated from.",
+		"tp_timestamp": "The date and time that the query was submitted, in ISO 8601 format and UTC.",
+	})
+}
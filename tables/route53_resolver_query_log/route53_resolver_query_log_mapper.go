@@ -0,0 +1,108 @@
+package route53_resolver_query_log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+)
+
+type Route53ResolverQueryLogMapper struct {
+}
+
+func (m *Route53ResolverQueryLogMapper) Identifier() string {
+	return "aws_route53_resolver_query_log_mapper"
+}
+
+func (m *Route53ResolverQueryLogMapper) Map(_ context.Context, a any, _ ...mappers.MapOption[*Route53ResolverQueryLog]) (*Route53ResolverQueryLog, error) {
+	var jsonBytes []byte
+
+	switch v := a.(type) {
+	case []byte:
+		jsonBytes = v
+	case string:
+		jsonBytes = []byte(v)
+	case *string:
+		jsonBytes = []byte(*v)
+	case cwTypes.FilteredLogEvent:
+		jsonBytes = []byte(*v.Message)
+	default:
+		return nil, fmt.Errorf("expected byte[] or string, got %T", a)
+	}
+
+	var log Route53ResolverQueryLog
+	if err := unmarshalRoute53ResolverQueryLog(jsonBytes, &log); err != nil {
+		return nil, fmt.Errorf("error decoding JSON: %w", err)
+	}
+
+	return &log, nil
+}
+
+func unmarshalRoute53ResolverQueryLog(data []byte, log *Route53ResolverQueryLog) error {
+	// the source port is logged as a string, so decode into a temporary struct and convert
+	var temp struct {
+		AccountId            *string  `json:"account_id"`
+		Answers              []Answer `json:"answers"`
+		FirewallDomainListId *string  `json:"firewall_domain_list_id"`
+		FirewallRuleAction   *string  `json:"firewall_rule_action"`
+		FirewallRuleGroupId  *string  `json:"firewall_rule_group_id"`
+		QueryClass           *string  `json:"query_class"`
+		QueryName            *string  `json:"query_name"`
+		QueryTimestamp       *string  `json:"query_timestamp"`
+		QueryType            *string  `json:"query_type"`
+		Rcode                *string  `json:"rcode"`
+		Region               *string  `json:"region"`
+		SrcAddr              *string  `json:"srcaddr"`
+		SrcIds               *SrcIds  `json:"srcids"`
+		SrcPort              *string  `json:"srcport"`
+		Transport            *string  `json:"transport"`
+		Version              *string  `json:"version"`
+		VpcId                *string  `json:"vpc_id"`
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	log.AccountId = temp.AccountId
+	log.Answers = temp.Answers
+	log.FirewallDomainListId = temp.FirewallDomainListId
+	log.FirewallRuleAction = temp.FirewallRuleAction
+	log.FirewallRuleGroupId = temp.FirewallRuleGroupId
+	log.QueryClass = temp.QueryClass
+	log.QueryName = temp.QueryName
+	log.QueryType = temp.QueryType
+	log.Rcode = temp.Rcode
+	log.Region = temp.Region
+	log.SrcAddr = temp.SrcAddr
+	log.SrcIds = temp.SrcIds
+	log.Transport = temp.Transport
+	log.Version = temp.Version
+	log.VpcId = temp.VpcId
+
+	if temp.QueryTimestamp == nil {
+		return fmt.Errorf("missing query_timestamp")
+	}
+	queryTimestamp, err := time.Parse(time.RFC3339, *temp.QueryTimestamp)
+	if err != nil {
+		return fmt.Errorf("error parsing query_timestamp: %w", err)
+	}
+	queryTimestamp = queryTimestamp.UTC()
+	log.QueryTimestamp = &queryTimestamp
+
+	if temp.SrcPort != nil && *temp.SrcPort != "" {
+		port, err := strconv.ParseInt(*temp.SrcPort, 10, 32)
+		if err != nil {
+			return fmt.Errorf("error parsing srcport: %w", err)
+		}
+		srcPort := int32(port)
+		log.SrcPort = &srcPort
+	}
+
+	return nil
+}
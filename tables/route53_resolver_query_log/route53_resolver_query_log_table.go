@@ -0,0 +1,95 @@
+package route53_resolver_query_log
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+)
+
+const Route53ResolverQueryLogTableIdentifier = "aws_route53_resolver_query_log"
+
+// Route53ResolverQueryLogTable - table for Route 53 Resolver query logs
+type Route53ResolverQueryLogTable struct{}
+
+func (c *Route53ResolverQueryLogTable) Identifier() string {
+	return Route53ResolverQueryLogTableIdentifier
+}
+
+func (c *Route53ResolverQueryLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*Route53ResolverQueryLog], error) {
+	// the default file layout for Resolver query logs in S3
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("AWSLogs/(%{DATA:org_id}/)?%{NUMBER:account_id}/vpcdnsquerylogs/%{DATA:vpc_id}/%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/%{DATA}.log.gz"),
+	}
+
+	return []*table.SourceMetadata[*Route53ResolverQueryLog]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Mapper:     &Route53ResolverQueryLogMapper{},
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithRowPerLine(),
+			},
+		},
+		{
+			// any artifact source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Mapper:     &Route53ResolverQueryLogMapper{},
+			Options:    []row_source.RowSourceOption{artifact_source.WithRowPerLine()},
+		},
+		{
+			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
+			Mapper:     &Route53ResolverQueryLogMapper{},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *Route53ResolverQueryLogTable) EnrichRow(row *Route53ResolverQueryLog, sourceEnrichmentFields schema.SourceEnrichment) (*Route53ResolverQueryLog, error) {
+	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+
+	// Record standardization
+	row.TpID = xid.New().String()
+	row.TpTimestamp = *row.QueryTimestamp
+	row.TpIngestTimestamp = time.Now()
+	row.TpDate = row.QueryTimestamp.Truncate(24 * time.Hour)
+
+	if row.QueryName != nil {
+		// query names are fully qualified, e.g. "example.com."
+		if domain := strings.TrimSuffix(*row.QueryName, "."); domain != "" {
+			row.TpDomains = append(row.TpDomains, domain)
+		}
+	}
+
+	if row.SrcAddr != nil {
+		row.TpSourceIP = row.SrcAddr
+		row.TpIps = append(row.TpIps, *row.SrcAddr)
+	}
+	for _, answer := range row.Answers {
+		if answer.Rdata == nil || answer.Type == nil {
+			continue
+		}
+		// only address records contain an IP address, other record types (e.g. CNAME) contain a domain name
+		if *answer.Type == "A" || *answer.Type == "AAAA" {
+			row.TpIps = append(row.TpIps, *answer.Rdata)
+		}
+	}
+
+	return row, nil
+}
+
+func (c *Route53ResolverQueryLogTable) GetDescription() string {
+	return "AWS Route 53 Resolver query logs record the DNS queries made by resources within a VPC, including the query name and type, the response code and answers, and any DNS Firewall rule that matched the query."
+}
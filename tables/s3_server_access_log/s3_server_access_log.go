@@ -5,11 +5,13 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
 type S3ServerAccessLog struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	AccessPointArn     *string   `json:"access_point_arn,omitempty"`
 	AclRequired        *bool     `json:"acl_required,omitempty"`
@@ -129,7 +131,7 @@ func (l *S3ServerAccessLog) InitialiseFromMap(m map[string]string) error {
 }
 
 func (c *S3ServerAccessLog) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"access_point_arn":    "The ARN of the S3 Access Point used for the request, if applicable.",
 		"acl_required":        "Indicates if ACLs were required for the request (true/false).",
 		"authentication_type": "The authentication method used (e.g., AuthHeader, QueryString).",
@@ -161,5 +163,5 @@ func (c *S3ServerAccessLog) GetColumnDescriptions() map[string]string {
 		"tp_index":     "The name of the S3 bucket where the request was made.",
 		"tp_ips":       "All IP addresses associated with the request, including the remote IP.",
 		"tp_usernames": "Canonical user IDs or role ARNs associated with the request.",
-	}
+	})
 }
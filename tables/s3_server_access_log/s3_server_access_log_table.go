@@ -54,6 +54,7 @@ func (c *S3ServerAccessLogTable) EnrichRow(row *S3ServerAccessLog, sourceEnrichm
 
 	// add any source enrichment fields
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	// Record standardization
 	row.TpID = xid.New().String()
@@ -4,11 +4,13 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
 type SecurityHubFinding struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	// Top level fields
 	Version    *string    `json:"version,omitempty"`
@@ -79,7 +81,7 @@ type DetailFindingsData struct {
 }
 
 func (c *SecurityHubFinding) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		// Top level fields
 		"version":     "The version of the event format.",
 		"id":          "The unique identifier for the event.",
@@ -137,5 +139,5 @@ func (c *SecurityHubFinding) GetColumnDescriptions() map[string]string {
 		"tp_akas":      "The list of AWS ARNs associated with the finding.",
 		"tp_timestamp": "The timestamp when the finding was generated.",
 		"tp_date":      "The date when the finding was generated, truncated to day.",
-	}
+	})
 }
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub/types"
 	"github.com/turbot/tailpipe-plugin-sdk/mappers"
 )
 
@@ -33,10 +36,37 @@ func (m *SecurityHubFindingMapper) Map(_ context.Context, a any, _ ...mappers.Ma
 	case *SecurityHubFinding:
 		b = *data
 		return &b, nil
+	case types.AwsSecurityFinding:
+		return m.mapAwsSecurityFinding(data)
+	case *types.AwsSecurityFinding:
+		return m.mapAwsSecurityFinding(*data)
 	default:
-		return nil, fmt.Errorf("expected byte[], string or SecurityHubFinding, got %T", a)
+		return nil, fmt.Errorf("expected byte[], string, SecurityHubFinding or AwsSecurityFinding, got %T", a)
 	}
 
 	return &b, nil
 
-}
\ No newline at end of file
+}
+
+// mapAwsSecurityFinding maps a finding returned by the Security Hub GetFindings API.
+// There is no EventBridge event for these findings, so the event account, region and time are taken from the finding.
+func (m *SecurityHubFindingMapper) mapAwsSecurityFinding(finding types.AwsSecurityFinding) (*SecurityHubFinding, error) {
+	event := DetailFindingsData{
+		Account: finding.AwsAccountId,
+		Region:  finding.Region,
+	}
+	if finding.UpdatedAt != nil {
+		updatedAt, err := time.Parse(time.RFC3339Nano, *finding.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing finding UpdatedAt: %w", err)
+		}
+		event.Time = &updatedAt
+	}
+	event.Detail.Findings = []types.AwsSecurityFinding{finding}
+
+	findings := toMapSecurityHubFinding(event)
+	if len(findings) != 1 {
+		return nil, fmt.Errorf("error mapping finding %s", aws.ToString(finding.Id))
+	}
+	return &findings[0], nil
+}
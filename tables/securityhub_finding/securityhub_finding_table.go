@@ -6,6 +6,7 @@ import (
 	"github.com/rs/xid"
 	"github.com/turbot/pipe-fittings/v2/utils"
 	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/sources/securityhub"
 	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
@@ -48,11 +49,16 @@ func (c *SecurityHubFindingTable) GetSourceMetadata() ([]*table.SourceMetadata[*
 				artifact_source.WithArtifactExtractor(NewSecurityHubFindingExtractor()),
 			},
 		},
+		{
+			SourceName: securityhub.AwsSecurityHubSourceIdentifier,
+			Mapper:     &SecurityHubFindingMapper{},
+		},
 	}, nil
 }
 
 func (c *SecurityHubFindingTable) EnrichRow(row *SecurityHubFinding, sourceEnrichmentFields schema.SourceEnrichment) (*SecurityHubFinding, error) {
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	row.TpID = xid.New().String()
 	row.TpIngestTimestamp = time.Now()
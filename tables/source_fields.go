@@ -0,0 +1,88 @@
+package tables
+
+import (
+	"maps"
+
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+)
+
+// the names of the fields captured from the artifact path by the default file layouts
+const (
+	sourceMetadataAccountId = "account_id"
+	sourceMetadataRegion    = "region"
+	sourceMetadataOrgId     = "org_id"
+)
+
+// the column names of the source fields, in the order they are added to custom table schemas
+var sourceFieldsColumnNames = []string{"source_account_id", "source_region", "source_org_id"}
+
+var sourceFieldsColumnDescriptions = map[string]string{
+	"source_account_id": "The AWS account ID captured from the path of the source artifact, e.g. the S3 object key. Set tp_index to source_account_id on the partition to index by account.",
+	"source_region":     "The AWS region captured from the path of the source artifact, e.g. the S3 object key.",
+	"source_org_id":     "The AWS Organizations organization ID captured from the path of the source artifact, for organization-wide logging.",
+}
+
+// SourceFields are the fields of a row populated from the artifact path, as captured by the file layout.
+// For example, AWSLogs/o-abcd1234/123456789012/CloudTrail/us-east-1/... captures the organization ID,
+// account ID and region. These allow rows to be filtered by account or region for log formats which do not
+// contain them.
+//
+// SourceFields should be embedded in the row struct of any table whose default file layout captures these fields.
+//
+// Note that tp_index cannot default to the source account ID here - the index is not part of the row enrichment,
+// but is set by the CLI from the tp_index of the partition, which defaults to "default". To index by account,
+// set tp_index = "source_account_id" on the partition.
+type SourceFields struct {
+	SourceAccountId *string `json:"source_account_id,omitempty"`
+	SourceRegion    *string `json:"source_region,omitempty"`
+	SourceOrgId     *string `json:"source_org_id,omitempty"`
+}
+
+// InitialiseFromMetadata populates the source fields from the source enrichment metadata
+// (fields which were not captured, or were captured as empty, are left as nil)
+func (f *SourceFields) InitialiseFromMetadata(metadata map[string]string) {
+	f.SourceAccountId = metadataValue(metadata, sourceMetadataAccountId)
+	f.SourceRegion = metadataValue(metadata, sourceMetadataRegion)
+	f.SourceOrgId = metadataValue(metadata, sourceMetadataOrgId)
+}
+
+// AddToOutputColumns adds the populated source fields to the output columns of a DynamicRow, for custom tables
+// which cannot embed SourceFields
+func (f *SourceFields) AddToOutputColumns(outputColumns map[string]any) {
+	if f.SourceAccountId != nil {
+		outputColumns["source_account_id"] = *f.SourceAccountId
+	}
+	if f.SourceRegion != nil {
+		outputColumns["source_region"] = *f.SourceRegion
+	}
+	if f.SourceOrgId != nil {
+		outputColumns["source_org_id"] = *f.SourceOrgId
+	}
+}
+
+// WithSourceFieldsColumnDescriptions adds the descriptions of the source fields to the given column descriptions
+func WithSourceFieldsColumnDescriptions(descriptions map[string]string) map[string]string {
+	maps.Copy(descriptions, sourceFieldsColumnDescriptions)
+	return descriptions
+}
+
+// SourceFieldsColumns returns the schema of the source field columns, for custom table schemas
+func SourceFieldsColumns() []*schema.ColumnSchema {
+	var columns []*schema.ColumnSchema
+	for _, name := range sourceFieldsColumnNames {
+		columns = append(columns, &schema.ColumnSchema{
+			ColumnName:  name,
+			Description: sourceFieldsColumnDescriptions[name],
+			Type:        "varchar",
+		})
+	}
+	return columns
+}
+
+func metadataValue(metadata map[string]string, key string) *string {
+	value, ok := metadata[key]
+	if !ok || value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,82 @@
+package transit_gateway_flow_log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/formats"
+)
+
+// the header fields which identify a Transit Gateway flow log file - a VPC flow log file contains neither
+var transitGatewayHeaderFields = []string{"resource-type", "tgw-id"}
+
+// TransitGatewayFlowLogExtractor is an extractor that receives the content of a Transit Gateway flow log file
+// and extracts a DynamicRow for each log record, using the header line (or the format layout) for the field names
+type TransitGatewayFlowLogExtractor struct {
+	Format formats.Format
+}
+
+// NewTransitGatewayFlowLogExtractor creates a new TransitGatewayFlowLogExtractor
+func NewTransitGatewayFlowLogExtractor(format formats.Format) artifact_source.Extractor {
+	return &TransitGatewayFlowLogExtractor{
+		Format: format,
+	}
+}
+
+func (c *TransitGatewayFlowLogExtractor) Identifier() string {
+	return "transit_gateway_flow_log_extractor"
+}
+
+// Extract splits the artifact data into lines and returns a DynamicRow for each log record.
+// Transit Gateway flow logs are delivered to the same S3 key prefix as VPC flow logs, so files with the header
+// of another format are skipped. Files with no header (e.g. delivered by Firehose) are parsed using the format layout.
+func (c *TransitGatewayFlowLogExtractor) Extract(_ context.Context, a any) ([]any, error) {
+	// The expected input type is the byte[] content of a flow log file
+	jsonBytes, ok := a.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected byte[], got %T", a)
+	}
+
+	fields := getValidTokensAndColumnNames()
+
+	keys, records, err := tables.SplitFlowLogHeader(string(jsonBytes), fields)
+	if err != nil {
+		return nil, fmt.Errorf("error in mapping the results to []map[string]string: %w", err)
+	}
+
+	switch {
+	case keys != nil && !isTransitGatewayHeader(keys):
+		// a header of fields common to VPC and Transit Gateway flow logs, without any Transit Gateway fields
+		slog.Debug("Skipping file without a Transit Gateway flow log header", "header", keys)
+		return []any{}, nil
+	case keys == nil && tables.IsFlowLogHeader(records[0]):
+		// a header containing fields which are not Transit Gateway fields, e.g. interface-id
+		slog.Debug("Skipping file without a Transit Gateway flow log header", "header", records[0])
+		return []any{}, nil
+	case keys == nil:
+		// Get the layout from the format
+		// If a format is specified in config it will be used, otherwise the default (version 6) format will be used.
+		// The default format has been defined under transit_gateway_flow_log_format_presets.go
+		prop := c.Format.GetProperties()
+		layout := prop["layout"]
+		keys = strings.Fields(layout)
+		slog.Warn("Header is not available in the log", "Using the default layout", layout)
+	}
+
+	return tables.FlowLogRows(keys, records, fields, TransitGatewayFlowLogTableNilValue)
+}
+
+// isTransitGatewayHeader returns whether the header contains any of the fields specific to Transit Gateway flow logs
+func isTransitGatewayHeader(header []string) bool {
+	for _, field := range transitGatewayHeaderFields {
+		if slices.Contains(header, field) {
+			return true
+		}
+	}
+	return false
+}
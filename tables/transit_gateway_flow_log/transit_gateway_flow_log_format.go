@@ -0,0 +1,149 @@
+package transit_gateway_flow_log
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/tailpipe-plugin-sdk/formats"
+	"github.com/turbot/tailpipe-plugin-sdk/mappers"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+type TransitGatewayFlowLogTableFormat struct {
+	// the name of this format instance
+	Name string `hcl:"name,label"`
+	// Description of the format
+	Description string `hcl:"description,optional"`
+	// the layout of the log line
+	Layout string `hcl:"layout"`
+}
+
+func NewTransitGatewayFlowLogTableFormat() formats.Format {
+	return &TransitGatewayFlowLogTableFormat{}
+}
+
+func (a *TransitGatewayFlowLogTableFormat) Validate() error {
+	var invalid []string
+	layoutParts := strings.Fields(a.Layout)
+	for _, part := range layoutParts {
+		if _, exists := getValidTokensAndColumnNames()[part]; !exists {
+			invalid = append(invalid, part)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("the following tokens are not valid: %s", strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
+// Identifier returns the format TYPE
+func (a *TransitGatewayFlowLogTableFormat) Identifier() string {
+	// format name is same as table name
+	return TransitGatewayFlowLogTableIdentifier
+}
+
+// GetName returns the format instance name
+func (a *TransitGatewayFlowLogTableFormat) GetName() string {
+	return a.Name
+}
+
+// SetName sets the name of this format instance
+func (a *TransitGatewayFlowLogTableFormat) SetName(name string) {
+	a.Name = name
+}
+
+func (a *TransitGatewayFlowLogTableFormat) GetDescription() string {
+	return a.Description
+}
+
+func (a *TransitGatewayFlowLogTableFormat) GetMapper() (mappers.Mapper[*types.DynamicRow], error) {
+	// convert the layout to a regex
+	regex, err := a.GetRegex()
+
+	if err != nil {
+		return nil, err
+	}
+	return mappers.NewRegexMapper[*types.DynamicRow](regex)
+}
+
+func (a *TransitGatewayFlowLogTableFormat) GetRegex() (string, error) {
+	// validate checks all tokens in layout are valid else returns error
+	err := a.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	tokens := strings.Fields(a.Layout)
+	var segments []string
+
+	// get the regex segment for each token
+	for _, token := range tokens {
+		segments = append(segments, getRegexForSegment(token))
+	}
+
+	// return the regex pattern (space separated)
+	return strings.Join(segments, " "), nil
+}
+
+func (a *TransitGatewayFlowLogTableFormat) GetProperties() map[string]string {
+	return map[string]string{
+		"layout": a.Layout,
+	}
+}
+
+func getRegexForSegment(segment string) string {
+	const defaultRegexFormat = "(?P<%s>[^ ]*)"
+
+	if columnName, exists := getValidTokensAndColumnNames()[segment]; exists {
+		return fmt.Sprintf(defaultRegexFormat, columnName)
+	}
+
+	return segment
+}
+
+func getValidTokensAndColumnNames() map[string]string {
+	return map[string]string{
+		"version":                   "version",
+		"resource-type":             "resource_type",
+		"account-id":                "account_id",
+		"tgw-id":                    "tgw_id",
+		"tgw-attachment-id":         "tgw_attachment_id",
+		"tgw-src-vpc-account-id":    "tgw_src_vpc_account_id",
+		"tgw-dst-vpc-account-id":    "tgw_dst_vpc_account_id",
+		"tgw-src-vpc-id":            "tgw_src_vpc_id",
+		"tgw-dst-vpc-id":            "tgw_dst_vpc_id",
+		"tgw-src-subnet-id":         "tgw_src_subnet_id",
+		"tgw-dst-subnet-id":         "tgw_dst_subnet_id",
+		"tgw-src-eni":               "tgw_src_eni",
+		"tgw-dst-eni":               "tgw_dst_eni",
+		"tgw-src-az-id":             "tgw_src_az_id",
+		"tgw-dst-az-id":             "tgw_dst_az_id",
+		"tgw-pair-attachment-id":    "tgw_pair_attachment_id",
+		"srcaddr":                   "src_addr",
+		"dstaddr":                   "dst_addr",
+		"srcport":                   "src_port",
+		"dstport":                   "dst_port",
+		"protocol":                  "protocol",
+		"packets":                   "packets",
+		"bytes":                     "bytes",
+		"start":                     "start_time",
+		"end":                       "end_time",
+		"log-status":                "log_status",
+		"type":                      "type",
+		"packets-lost-no-route":     "packets_lost_no_route",
+		"packets-lost-blackhole":    "packets_lost_blackhole",
+		"packets-lost-mtu-exceeded": "packets_lost_mtu_exceeded",
+		"packets-lost-ttl-expired":  "packets_lost_ttl_expired",
+		"tcp-flags":                 "tcp_flags",
+		"region":                    "region",
+		"flow-direction":            "flow_direction",
+		"pkt-src-aws-service":       "pkt_src_aws_service",
+		"pkt-dst-aws-service":       "pkt_dst_aws_service",
+
+		// This is not present in the log but is added by AWS while exporting the log from CloudWatch to S3,
+		// the timestamp is the time when the log was exported.
+		"export-timestamp": "export_timestamp",
+	}
+}
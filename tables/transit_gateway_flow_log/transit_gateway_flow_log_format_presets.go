@@ -0,0 +1,13 @@
+package transit_gateway_flow_log
+
+import sdkformats "github.com/turbot/tailpipe-plugin-sdk/formats"
+
+var defaultTransitGatewayFlowLogTableFormat = &TransitGatewayFlowLogTableFormat{
+	Name:        "default",
+	Description: "The default format for a Transit Gateway Flow Log (version 6).",
+	Layout:      `version resource-type account-id tgw-id tgw-attachment-id tgw-src-vpc-account-id tgw-dst-vpc-account-id tgw-src-vpc-id tgw-dst-vpc-id tgw-src-subnet-id tgw-dst-subnet-id tgw-src-eni tgw-dst-eni tgw-src-az-id tgw-dst-az-id tgw-pair-attachment-id srcaddr dstaddr srcport dstport protocol packets bytes start end log-status type packets-lost-no-route packets-lost-blackhole packets-lost-mtu-exceeded packets-lost-ttl-expired tcp-flags region flow-direction pkt-src-aws-service pkt-dst-aws-service`,
+}
+
+var TransitGatewayFlowLogTableFormatPresets = []sdkformats.Format{
+	defaultTransitGatewayFlowLogTableFormat,
+}
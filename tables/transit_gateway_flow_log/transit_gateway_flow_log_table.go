@@ -0,0 +1,330 @@
+package transit_gateway_flow_log
+
+import (
+	"fmt"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/pipe-fittings/v2/utils"
+	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
+	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
+	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
+	"github.com/turbot/tailpipe-plugin-sdk/constants"
+	"github.com/turbot/tailpipe-plugin-sdk/error_types"
+	"github.com/turbot/tailpipe-plugin-sdk/formats"
+	"github.com/turbot/tailpipe-plugin-sdk/row_source"
+	"github.com/turbot/tailpipe-plugin-sdk/schema"
+	"github.com/turbot/tailpipe-plugin-sdk/table"
+	"github.com/turbot/tailpipe-plugin-sdk/types"
+)
+
+const TransitGatewayFlowLogTableIdentifier = "aws_transit_gateway_flow_log"
+const TransitGatewayFlowLogTableNilValue = "-"
+
+// TransitGatewayFlowLogTable - table for Transit Gateway Flow Logs
+type TransitGatewayFlowLogTable struct {
+	table.CustomTableImpl
+}
+
+func (c *TransitGatewayFlowLogTable) Identifier() string {
+	return TransitGatewayFlowLogTableIdentifier
+}
+
+func (c *TransitGatewayFlowLogTable) GetDefaultFormat() formats.Format {
+	return defaultTransitGatewayFlowLogTableFormat
+}
+
+func (c *TransitGatewayFlowLogTable) GetTableDefinition() *schema.TableSchema {
+	return &schema.TableSchema{
+		Name: TransitGatewayFlowLogTableIdentifier,
+		Columns: append([]*schema.ColumnSchema{
+			// version 6 (default) fields
+			{
+				ColumnName:  "version",
+				Description: "The Transit Gateway Flow Logs version.",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "resource_type",
+				Description: "The type of resource on which the subscription is created. For Transit Gateway flow logs, this is TransitGateway or TransitGatewayAttachment.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "account_id",
+				Description: "The AWS account ID of the transit gateway owner.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_id",
+				Description: "The ID of the transit gateway for which traffic is recorded.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_attachment_id",
+				Description: "The ID of the transit gateway attachment for which traffic is recorded.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_src_vpc_account_id",
+				Description: "The AWS account ID of the source VPC traffic.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_dst_vpc_account_id",
+				Description: "The AWS account ID of the destination VPC traffic.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_src_vpc_id",
+				Description: "The ID of the source VPC for the transit gateway.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_dst_vpc_id",
+				Description: "The ID of the destination VPC for the transit gateway.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_src_subnet_id",
+				Description: "The ID of the subnet for the source of the transit gateway traffic.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_dst_subnet_id",
+				Description: "The ID of the subnet for the destination of the transit gateway traffic.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_src_eni",
+				Description: "The ID of the source transit gateway attachment network interface for the flow.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_dst_eni",
+				Description: "The ID of the destination transit gateway attachment network interface for the flow.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_src_az_id",
+				Description: "The ID of the Availability Zone that contains the source transit gateway attachment network interface.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_dst_az_id",
+				Description: "The ID of the Availability Zone that contains the destination transit gateway attachment network interface.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "tgw_pair_attachment_id",
+				Description: "The ID of the opposite (ingress or egress) transit gateway attachment for the flow, depending on the flow direction.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "src_addr",
+				Description: "The source IP address of the incoming traffic.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "dst_addr",
+				Description: "The destination IP address of the outgoing traffic.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "src_port",
+				Description: "The source port of the traffic.",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "dst_port",
+				Description: "The destination port of the traffic.",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "protocol",
+				Description: "The IANA protocol number of the traffic (e.g., 6 for TCP, 17 for UDP).",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "packets",
+				Description: "The number of packets transferred during the flow.",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "bytes",
+				Description: "The number of bytes transferred during the flow.",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "start_time",
+				Description: "The time, in Unix seconds, when the first packet of the flow was received within the aggregation interval.",
+				Type:        "timestamp",
+			},
+			{
+				ColumnName:  "end_time",
+				Description: "The time, in Unix seconds, when the last packet of the flow was received within the aggregation interval.",
+				Type:        "timestamp",
+			},
+			{
+				ColumnName:  "log_status",
+				Description: "The logging status of the flow log: OK, NODATA, or SKIPDATA.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "type",
+				Description: "The type of traffic (IPv4, IPv6, or EFA).",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "packets_lost_no_route",
+				Description: "The number of packets lost because there was no route.",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "packets_lost_blackhole",
+				Description: "The number of packets lost due to a blackhole route.",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "packets_lost_mtu_exceeded",
+				Description: "The number of packets lost because their size exceeded the MTU.",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "packets_lost_ttl_expired",
+				Description: "The number of packets lost because their time-to-live expired.",
+				Type:        "bigint",
+			},
+			{
+				ColumnName:  "tcp_flags",
+				Description: "The bitmask value for TCP flags recorded during the flow.",
+				Type:        "integer",
+			},
+			{
+				ColumnName:  "region",
+				Description: "The AWS region of the transit gateway.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "flow_direction",
+				Description: "The direction of the flow with respect to the transit gateway attachment: ingress or egress.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "pkt_src_aws_service",
+				Description: "The AWS service associated with the packet source IP, if applicable.",
+				Type:        "varchar",
+			},
+			{
+				ColumnName:  "pkt_dst_aws_service",
+				Description: "The AWS service associated with the packet destination IP, if applicable.",
+				Type:        "varchar",
+			},
+		}, tables.SourceFieldsColumns()...),
+		NullIf:      TransitGatewayFlowLogTableNilValue,
+		Description: c.GetDescription(),
+	}
+}
+
+func (c *TransitGatewayFlowLogTable) GetSourceMetadata() ([]*table.SourceMetadata[*types.DynamicRow], error) {
+	// Transit Gateway flow logs are delivered to S3 using the same key prefix as VPC flow logs
+	defaultS3ArtifactConfig := &artifact_source_config.ArtifactSourceConfigImpl{
+		FileLayout: utils.ToStringPointer("AWSLogs/(%{DATA:org_id}/)?%{NUMBER:account_id}/vpcflowlogs/%{DATA:region}/%{YEAR:year}/%{MONTHNUM:month}/%{MONTHDAY:day}/(%{NUMBER:hour}/)?%{DATA}.log.gz"),
+	}
+
+	// Create a custom CloudWatch mapper that can extract the message from the complete event JSON
+	format, ok := c.Format.(*TransitGatewayFlowLogTableFormat)
+	if !ok {
+		return nil, fmt.Errorf("invalid format type: expected *TransitGatewayFlowLogTableFormat")
+	}
+
+	cloudWatchMapper, err := NewTransitGatewayFlowLogCloudWatchMapper(format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CloudWatch mapper: %w", err)
+	}
+
+	return []*table.SourceMetadata[*types.DynamicRow]{
+		{
+			// S3 artifact source
+			SourceName: s3_bucket.AwsS3BucketSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithDefaultArtifactSourceConfig(defaultS3ArtifactConfig),
+				artifact_source.WithArtifactExtractor(NewTransitGatewayFlowLogExtractor(c.Format)),
+			},
+		},
+		{
+			// CloudWatch source
+			SourceName: cloudwatch_log_group.AwsCloudwatchLogGroupSourceIdentifier,
+			Mapper:     cloudWatchMapper,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithRowPerLine(),
+			},
+		},
+		{
+			// File source
+			SourceName: constants.ArtifactSourceIdentifier,
+			Options: []row_source.RowSourceOption{
+				artifact_source.WithArtifactExtractor(NewTransitGatewayFlowLogExtractor(c.Format)),
+			},
+		},
+	}, nil
+}
+
+// EnrichRow implements table.Table
+func (c *TransitGatewayFlowLogTable) EnrichRow(row *types.DynamicRow, sourceEnrichmentFields schema.SourceEnrichment) (*types.DynamicRow, error) {
+	var invalidFields []string
+
+	row.OutputColumns[constants.TpTable] = TransitGatewayFlowLogTableIdentifier
+
+	// Process end_time first
+	if endTime, ok := row.GetSourceValue("end_time"); ok && endTime != TransitGatewayFlowLogTableNilValue {
+		t, err := helpers.ParseTime(endTime)
+		if err != nil {
+			invalidFields = append(invalidFields, "end_time")
+		} else {
+			row.OutputColumns["end_time"] = t
+			row.OutputColumns[constants.TpTimestamp] = t
+		}
+	}
+
+	// Process start_time second (with precedence for TpTimestamp)
+	if startTime, ok := row.GetSourceValue("start_time"); ok && startTime != TransitGatewayFlowLogTableNilValue {
+		t, err := helpers.ParseTime(startTime)
+		if err != nil {
+			invalidFields = append(invalidFields, "start_time")
+		} else {
+			row.OutputColumns["start_time"] = t
+			row.OutputColumns[constants.TpTimestamp] = t
+		}
+	}
+
+	if len(invalidFields) > 0 {
+		return nil, error_types.NewRowErrorWithFields([]string{}, invalidFields)
+	}
+
+	// tp_ips
+	var ips []string
+	if srcAddr, ok := row.GetSourceValue("src_addr"); ok && srcAddr != TransitGatewayFlowLogTableNilValue {
+		ips = append(ips, srcAddr)
+		row.OutputColumns[constants.TpSourceIP] = srcAddr
+	}
+	if dstAddr, ok := row.GetSourceValue("dst_addr"); ok && dstAddr != TransitGatewayFlowLogTableNilValue {
+		ips = append(ips, dstAddr)
+		row.OutputColumns[constants.TpDestinationIP] = dstAddr
+	}
+	if len(ips) > 0 {
+		row.OutputColumns[constants.TpIps] = ips
+	}
+
+	// source fields captured from the artifact path
+	var sourceFields tables.SourceFields
+	sourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+	sourceFields.AddToOutputColumns(row.OutputColumns)
+
+	// now call the base class to do the rest of the enrichment
+	return c.CustomTableImpl.EnrichRow(row, sourceEnrichmentFields)
+}
+
+func (c *TransitGatewayFlowLogTable) GetDescription() string {
+	return "AWS Transit Gateway Flow Logs capture information about IP traffic going to and from transit gateway attachments. This table provides detailed network traffic patterns between the VPCs, VPNs and Direct Connect gateways attached to a transit gateway, including source and destination VPCs, subnets and addresses, traffic volumes and dropped packets."
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/turbot/pipe-fittings/v2/utils"
 	"github.com/turbot/tailpipe-plugin-aws/sources/cloudwatch_log_group"
 	"github.com/turbot/tailpipe-plugin-aws/sources/s3_bucket"
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source"
 	"github.com/turbot/tailpipe-plugin-sdk/artifact_source_config"
 	"github.com/turbot/tailpipe-plugin-sdk/constants"
@@ -37,7 +38,7 @@ func (c *VpcFlowLogTable) GetDefaultFormat() formats.Format {
 func (c *VpcFlowLogTable) GetTableDefinition() *schema.TableSchema {
 	return &schema.TableSchema{
 		Name: VpcFlowLogTableIdentifier,
-		Columns: []*schema.ColumnSchema{
+		Columns: append([]*schema.ColumnSchema{
 			// version 2 (default) fields
 			{
 				ColumnName:  "version",
@@ -244,7 +245,7 @@ func (c *VpcFlowLogTable) GetTableDefinition() *schema.TableSchema {
 				Description: "The reason why traffic was rejected (e.g., BPA).",
 				Type:        "varchar",
 			},
-		},
+		}, tables.SourceFieldsColumns()...),
 		NullIf:      VpcFlowLogTableNilValue,
 		Description: c.GetDescription(),
 	}
@@ -368,6 +369,11 @@ func (c *VpcFlowLogTable) EnrichRow(row *types.DynamicRow, sourceEnrichmentField
 		row.OutputColumns[constants.TpAkas] = akas
 	}
 
+	// source fields captured from the artifact path
+	var sourceFields tables.SourceFields
+	sourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
+	sourceFields.AddToOutputColumns(row.OutputColumns)
+
 	// now call the base class to do the rest of the enrichment
 	return c.CustomTableImpl.EnrichRow(row, sourceEnrichmentFields)
 }
@@ -3,6 +3,7 @@ package waf_traffic_log
 import (
 	"time"
 
+	"github.com/turbot/tailpipe-plugin-aws/tables"
 	"github.com/turbot/tailpipe-plugin-sdk/schema"
 )
 
@@ -90,6 +91,7 @@ type Labels struct {
 // WafTrafficLog struct with fields aligned to the provided JSON
 type WafTrafficLog struct {
 	schema.CommonFields
+	tables.SourceFields
 
 	Action                      *string                   `json:"action"`
 	CaptchaResponse             *CaptchaResponse          `json:"captchaResponse,omitempty" parquet:"name=captcha_response"`
@@ -110,7 +112,7 @@ type WafTrafficLog struct {
 }
 
 func (c *WafTrafficLog) GetColumnDescriptions() map[string]string {
-	return map[string]string{
+	return tables.WithSourceFieldsColumnDescriptions(map[string]string{
 		"action":                         "The terminating action that AWS WAF applied to the request. This indicates either allow, block, CAPTCHA, or challenge. The CAPTCHA and Challenge actions are terminating when the web request doesn't contain a valid token.",
 		"captcha_response":               "The CAPTCHA action status for the request, populated when a CAPTCHA action is applied to the request. This field is populated for any CAPTCHA action, whether terminating or non-terminating. If a request has the CAPTCHA action applied multiple times, this field is populated from the last time the action was applied.",
 		"format_version":                 "The format version for the log.",
@@ -133,5 +135,5 @@ func (c *WafTrafficLog) GetColumnDescriptions() map[string]string {
 		"tp_index":     "The AWS Web ACL ID that processed or received the request.",
 		"tp_ips":       "IP addresses related to the request, including the source (client) IP and any intermediary addresses.",
 		"tp_timestamp": "The timestamp when the request was made, formatted in ISO 8601 (UTC).",
-	}
+	})
 }
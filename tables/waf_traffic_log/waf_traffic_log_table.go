@@ -59,6 +59,7 @@ func (c *WafTrafficLogTable) Identifier() string {
 // EnrichRow implements table.Table
 func (c *WafTrafficLogTable) EnrichRow(row *WafTrafficLog, sourceEnrichmentFields schema.SourceEnrichment) (*WafTrafficLog, error) { // initialize the enrichment fields to any fields provided by the source
 	row.CommonFields = sourceEnrichmentFields.CommonFields
+	row.SourceFields.InitialiseFromMetadata(sourceEnrichmentFields.Metadata)
 
 	// Record standardization
 	row.TpID = xid.New().String()